@@ -0,0 +1,61 @@
+package actionlog
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/cel-go/cel"
+	"github.com/passbolt/go-passbolt-cli/util"
+)
+
+// Environments for CEL
+var celEnvOptions = []cel.EnvOption{
+	cel.Variable("ID", cel.StringType),
+	cel.Variable("Action", cel.StringType),
+	cel.Variable("UserID", cel.StringType),
+	cel.Variable("Username", cel.StringType),
+	cel.Variable("Details", cel.StringType),
+	cel.Variable("Data", cel.MapType(cel.StringType, cel.DynType)),
+	cel.Variable("CreatedTimestamp", cel.TimestampType),
+}
+
+// actionLogCelVars returns the CEL activation for an Action Log Entry
+func actionLogCelVars(log util.ActionLog) map[string]any {
+	data := map[string]any{}
+	json.Unmarshal(log.Data, &data)
+
+	return map[string]any{
+		"ID":               log.ID,
+		"Action":           log.Type,
+		"UserID":           userID(log),
+		"Username":         username(log),
+		"Details":          util.ActionLogDetails(log.Data),
+		"Data":             data,
+		"CreatedTimestamp": created(log),
+	}
+}
+
+// filterActionLogs filters the Action Logs by invoking the CEL program for each Entry
+func filterActionLogs(ctx context.Context, logs []util.ActionLog, celCmd string) ([]util.ActionLog, error) {
+	if celCmd == "" {
+		return logs, nil
+	}
+
+	program, err := util.InitCELProgram(celCmd, celEnvOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []util.ActionLog{}
+	for _, log := range logs {
+		val, _, err := (*program).ContextEval(ctx, actionLogCelVars(log))
+		if err != nil {
+			return nil, err
+		}
+
+		if val.Value() == true {
+			filtered = append(filtered, log)
+		}
+	}
+	return filtered, nil
+}
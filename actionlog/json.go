@@ -0,0 +1,15 @@
+package actionlog
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ActionLogJsonOutput struct {
+	ID               *string         `json:"id,omitempty"`
+	CreatedTimestamp *time.Time      `json:"created_timestamp,omitempty"`
+	Action           *string         `json:"action,omitempty"`
+	UserID           *string         `json:"user_id,omitempty"`
+	Username         *string         `json:"username,omitempty"`
+	Data             json.RawMessage `json:"data,omitempty"`
+}
@@ -0,0 +1,490 @@
+package actionlog
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/resource"
+	"github.com/passbolt/go-passbolt-cli/user"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// pageSize is the Number of Action Logs requested at once
+const pageSize = 100
+
+var defaultTableColumns = []string{"CreatedTimestamp", "Username", "Action", "Details"}
+
+// ActionLogListCmd Lists Passbolt Action Logs
+var ActionLogListCmd = &cobra.Command{
+	Use:   "actionlog",
+	Short: "Lists Passbolt Action Logs",
+	Long: `Lists the Action Logs of a Resource (--resource) or Folder (--folder), newest first.
+The Server only provides Action Logs per Entity (Passbolt v3 or newer), there is no Listing for the whole Instance.
+With --follow new Entries are printed as they appear until interrupted, JSON is then printed as one Object per Line.`,
+	Aliases: []string{"actionlogs"},
+	Args:    cobra.NoArgs,
+	RunE:    ActionLogList,
+}
+
+func init() {
+	flags := ActionLogListCmd.Flags()
+	flags.String("user", "", "Only Entries of the User with this id, username or name")
+	flags.String("resource", "", "Only Entries of the Resource with this id, name or path")
+	flags.String("folder", "", "Only Entries of the Folder with this id or path")
+	flags.String("since", "", "Only Entries after this Time, as RFC3339, date (e.g. 2025-12-31) or duration ago (e.g. 7d)")
+	flags.String("until", "", "Only Entries before this Time, as RFC3339, date (e.g. 2025-12-31) or duration ago (e.g. 1d)")
+	flags.StringArray("action", []string{}, "Only Entries whose Action contains this Text (case insensitive), e.g. Permissions. Can be specified multiple times")
+	flags.Bool("csv", false, "Output CSV")
+	flags.BoolP("follow", "F", false, "Keep printing new Entries as they appear")
+	flags.Duration("interval", 10*time.Second, "Polling Interval for --follow")
+	flags.StringArrayP("column", "c", defaultTableColumns, "Columns to return for table and CSV output.\nPossible Columns: ID, CreatedTimestamp, Action, UserID, Username, Details")
+	ActionLogListCmd.RegisterFlagCompletionFunc("user", user.CompleteUserIDs)
+	ActionLogListCmd.RegisterFlagCompletionFunc("resource", resource.CompleteResourceIDs)
+	ActionLogListCmd.RegisterFlagCompletionFunc("folder", folder.CompleteFolderIDs)
+}
+
+type actionLogListConfig struct {
+	user       string
+	resource   string
+	folder     string
+	since      time.Time
+	until      time.Time
+	actions    []string
+	columns    []string
+	jsonOutput bool
+	csvOutput  bool
+	follow     bool
+	interval   time.Duration
+	celFilter  string
+	sortKeys   []util.SortKey
+	limit      int
+	offset     int
+}
+
+func ActionLogList(cmd *cobra.Command, args []string) error {
+	config, err := parseActionLogListFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	// --follow runs until interrupted, so only single Requests are limited by --timeout
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if config.follow {
+		ctx, cancel = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	} else {
+		ctx, cancel = util.GetContext()
+	}
+	defer cancel()
+
+	loginCtx, loginCancel := context.WithTimeout(ctx, viper.GetDuration("timeout"))
+	defer loginCancel()
+	client, err := util.GetClient(loginCtx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		logoutCtx, logoutCancel := util.GetContext()
+		defer logoutCancel()
+		util.SaveSessionKeysAndLogout(logoutCtx, client)
+	}()
+	cmd.SilenceUsage = true
+
+	var path string
+	if config.resource != "" {
+		id, err := resource.ResolveResourceID(loginCtx, client, config.resource)
+		if err != nil {
+			return fmt.Errorf("Resolving Resource: %w", err)
+		}
+		path = "/actionlog/resource/" + id + ".json"
+	} else {
+		id, err := folder.ResolveFolderID(loginCtx, client, config.folder)
+		if err != nil {
+			return fmt.Errorf("Resolving Folder: %w", err)
+		}
+		path = "/actionlog/folder/" + id + ".json"
+	}
+	if config.user != "" {
+		config.user, err = util.ResolveUserID(loginCtx, client, config.user)
+		if err != nil {
+			return fmt.Errorf("Resolving User: %w", err)
+		}
+	}
+
+	fetchCtx, fetchCancel := context.WithTimeout(ctx, viper.GetDuration("timeout"))
+	defer fetchCancel()
+	logs, err := fetchActionLogs(fetchCtx, client, path, config.since)
+	if err != nil {
+		return fmt.Errorf("Listing Action Logs: %w", err)
+	}
+
+	// Remember everything fetched so far, --follow only prints Entries which are new
+	seen := map[string]bool{}
+	for _, log := range logs {
+		seen[log.ID] = true
+	}
+
+	logs, err = filterActionLogs(ctx, matchingActionLogs(logs, config), config.celFilter)
+	if err != nil {
+		return err
+	}
+
+	if config.follow {
+		// Oldest first, so that new Entries can simply be appended
+		slices.Reverse(logs)
+		p := newActionLogPrinter(config)
+		err = p.print(logs)
+		if err != nil {
+			return err
+		}
+		return followActionLogs(ctx, client, path, config, seen, p)
+	}
+
+	err = util.SortByKeys(ctx, logs, config.sortKeys, actionLogCelVars, celEnvOptions...)
+	if err != nil {
+		return err
+	}
+	logs = util.Paginate(logs, config.offset, config.limit)
+	return newActionLogPrinter(config).print(logs)
+}
+
+// fetchActionLogs gets all Action Logs of path, stopping at the first Page older than since
+func fetchActionLogs(ctx context.Context, client *api.Client, path string, since time.Time) ([]util.ActionLog, error) {
+	logs := []util.ActionLog{}
+	seen := map[string]bool{}
+	for page := 1; ; page++ {
+		batch, err := util.GetActionLogs(ctx, client, path, util.GetActionLogsOptions{
+			Limit: pageSize,
+			Page:  page,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Protects against Servers ignoring the Page and returning the same Entries again
+		if len(batch) == 0 || seen[batch[0].ID] {
+			return logs, nil
+		}
+		for _, log := range batch {
+			seen[log.ID] = true
+		}
+		logs = append(logs, batch...)
+
+		if len(batch) < pageSize || (!since.IsZero() && created(batch[len(batch)-1]).Before(since)) {
+			return logs, nil
+		}
+	}
+}
+
+// followActionLogs polls the first Page of Action Logs and prints Entries which have not been seen yet
+func followActionLogs(ctx context.Context, client *api.Client, path string, config *actionLogListConfig, seen map[string]bool, p *actionLogPrinter) error {
+	ticker := time.NewTicker(config.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		pollCtx, pollCancel := context.WithTimeout(ctx, viper.GetDuration("timeout"))
+		batch, err := util.GetActionLogs(pollCtx, client, path, util.GetActionLogsOptions{
+			Limit: pageSize,
+			Page:  1,
+		})
+		pollCancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("Listing Action Logs: %w", err)
+		}
+
+		logs := []util.ActionLog{}
+		for _, log := range batch {
+			if !seen[log.ID] {
+				seen[log.ID] = true
+				logs = append(logs, log)
+			}
+		}
+
+		logs, err = filterActionLogs(ctx, matchingActionLogs(logs, config), config.celFilter)
+		if err != nil {
+			return err
+		}
+		slices.Reverse(logs)
+		err = p.print(logs)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// matchingActionLogs returns the Action Logs matching --user, --since, --until and --action
+func matchingActionLogs(logs []util.ActionLog, config *actionLogListConfig) []util.ActionLog {
+	matching := []util.ActionLog{}
+	for _, log := range logs {
+		if config.user != "" && userID(log) != config.user {
+			continue
+		}
+		if !config.since.IsZero() && created(log).Before(config.since) {
+			continue
+		}
+		if !config.until.IsZero() && created(log).After(config.until) {
+			continue
+		}
+		if len(config.actions) != 0 && !slices.ContainsFunc(config.actions, func(action string) bool {
+			return strings.Contains(strings.ToLower(log.Type), strings.ToLower(action))
+		}) {
+			continue
+		}
+		matching = append(matching, log)
+	}
+	return matching
+}
+
+// actionLogPrinter prints Action Logs as Table, CSV or JSON, the Header is only printed once
+type actionLogPrinter struct {
+	config        *actionLogListConfig
+	headerPrinted bool
+}
+
+func newActionLogPrinter(config *actionLogListConfig) *actionLogPrinter {
+	return &actionLogPrinter{config: config}
+}
+
+func (p *actionLogPrinter) print(logs []util.ActionLog) error {
+	if p.config.jsonOutput {
+		return p.printJson(logs)
+	}
+
+	rows := [][]string{}
+	if !p.headerPrinted {
+		rows = append(rows, p.config.columns)
+	}
+	for _, log := range logs {
+		row, err := actionLogRow(log, p.config.columns)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+
+	if p.config.csvOutput {
+		w := csv.NewWriter(os.Stdout)
+		err := w.WriteAll(rows)
+		if err != nil {
+			return fmt.Errorf("Writing CSV: %w", err)
+		}
+		p.headerPrinted = true
+		return nil
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+	err := pterm.DefaultTable.WithHasHeader(!p.headerPrinted).WithData(rows).Render()
+	if err != nil {
+		return err
+	}
+	p.headerPrinted = true
+	return nil
+}
+
+func (p *actionLogPrinter) printJson(logs []util.ActionLog) error {
+	output := make([]ActionLogJsonOutput, len(logs))
+	for i := range logs {
+		createdTime := created(logs[i])
+		output[i] = ActionLogJsonOutput{
+			ID:               &logs[i].ID,
+			CreatedTimestamp: &createdTime,
+			Action:           &logs[i].Type,
+			Data:             logs[i].Data,
+		}
+		if logs[i].Creator != nil {
+			output[i].UserID = &logs[i].Creator.ID
+			output[i].Username = &logs[i].Creator.Username
+		}
+	}
+
+	// In follow Mode every Entry is printed as its own Line
+	if p.config.follow {
+		for _, o := range output {
+			line, err := json.Marshal(o)
+			if err != nil {
+				return fmt.Errorf("Marshalling Json: %w", err)
+			}
+			fmt.Println(string(line))
+		}
+		return nil
+	}
+
+	jsonLogs, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Marshalling Json: %w", err)
+	}
+	fmt.Println(string(jsonLogs))
+	return nil
+}
+
+func actionLogRow(log util.ActionLog, columns []string) ([]string, error) {
+	row := make([]string, len(columns))
+	for i := range columns {
+		switch strings.ToLower(columns[i]) {
+		case "id":
+			row[i] = log.ID
+		case "createdtimestamp":
+			row[i] = created(log).Format(time.RFC3339)
+		case "action":
+			row[i] = shellescape.StripUnsafe(log.Type)
+		case "userid":
+			row[i] = userID(log)
+		case "username":
+			row[i] = shellescape.StripUnsafe(username(log))
+		case "details":
+			row[i] = util.ActionLogDetails(log.Data)
+		default:
+			return nil, fmt.Errorf("Unknown Column: %v", columns[i])
+		}
+	}
+	return row, nil
+}
+
+func created(log util.ActionLog) time.Time {
+	if log.Created == nil {
+		return time.Time{}
+	}
+	return log.Created.Time
+}
+
+func userID(log util.ActionLog) string {
+	if log.Creator == nil {
+		return ""
+	}
+	return log.Creator.ID
+}
+
+func username(log util.ActionLog) string {
+	if log.Creator == nil {
+		return ""
+	}
+	return log.Creator.Username
+}
+
+func parseActionLogListFlags(cmd *cobra.Command) (*actionLogListConfig, error) {
+	userInput, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return nil, err
+	}
+	resourceInput, err := cmd.Flags().GetString("resource")
+	if err != nil {
+		return nil, err
+	}
+	folderInput, err := cmd.Flags().GetString("folder")
+	if err != nil {
+		return nil, err
+	}
+	if (resourceInput == "") == (folderInput == "") {
+		return nil, fmt.Errorf("Either --resource or --folder is required")
+	}
+
+	now := time.Now()
+	var since, until time.Time
+	sinceInput, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return nil, err
+	}
+	if sinceInput != "" {
+		since, err = util.ParsePastTime(sinceInput, now)
+		if err != nil {
+			return nil, fmt.Errorf("Parsing --since: %w", err)
+		}
+	}
+	untilInput, err := cmd.Flags().GetString("until")
+	if err != nil {
+		return nil, err
+	}
+	if untilInput != "" {
+		until, err = util.ParsePastTime(untilInput, now)
+		if err != nil {
+			return nil, fmt.Errorf("Parsing --until: %w", err)
+		}
+	}
+
+	actions, err := cmd.Flags().GetStringArray("action")
+	if err != nil {
+		return nil, err
+	}
+	columns, err := cmd.Flags().GetStringArray("column")
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("You need to specify atleast one column to return")
+	}
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return nil, err
+	}
+	csvOutput, err := cmd.Flags().GetBool("csv")
+	if err != nil {
+		return nil, err
+	}
+	if jsonOutput && csvOutput {
+		return nil, fmt.Errorf("--json and --csv can't be combined")
+	}
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		return nil, err
+	}
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("--interval must be positive")
+	}
+	celFilter, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		return nil, err
+	}
+	sortKeys, limit, offset, err := util.GetSortAndPagination(cmd, celEnvOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if follow && (len(sortKeys) != 0 || limit != 0 || offset != 0) {
+		return nil, fmt.Errorf("--follow can't be combined with --sort, --limit or --offset")
+	}
+
+	return &actionLogListConfig{
+		user:       userInput,
+		resource:   resourceInput,
+		folder:     folderInput,
+		since:      since,
+		until:      until,
+		actions:    actions,
+		columns:    columns,
+		jsonOutput: jsonOutput,
+		csvOutput:  csvOutput,
+		follow:     follow,
+		interval:   interval,
+		celFilter:  celFilter,
+		sortKeys:   sortKeys,
+		limit:      limit,
+		offset:     offset,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/spf13/viper"
+)
+
+// cacheFile is the on-disk format, Data is a PGP Message encrypted to the Users Key
+type cacheFile struct {
+	Updated time.Time `json:"updated"`
+	Data    string    `json:"data"`
+}
+
+// Dir returns the Cache Directory for the configured Server and User,
+// so that multiple configs don't share a Cache.
+func Dir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "go-passbolt-cli", configHash()), nil
+}
+
+// DataDir returns the Data Directory for the configured Server and User.
+// Unlike the Cache Directory it lives next to the Config, is not removed by Clear and not purged by the OS,
+// so it holds Files which can't simply be fetched again like Snapshots.
+func DataDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "go-passbolt-cli", "data", configHash()), nil
+}
+
+// configHash identifies the configured Server and User
+func configHash() string {
+	sum := sha256.Sum256([]byte(viper.GetString("serverAddress") + "\n" + viper.GetString("userPrivateKey")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Save encrypts v to the Users Key and writes it to the named Cache File.
+func Save(client *api.Client, name string, v any) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	return save(client, dir, name, v)
+}
+
+// SaveData encrypts v to the Users Key and writes it to the named File in the Data Directory.
+func SaveData(client *api.Client, name string, v any) error {
+	dir, err := DataDir()
+	if err != nil {
+		return err
+	}
+	return save(client, dir, name, v)
+}
+
+func save(client *api.Client, dir, name string, v any) error {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return fmt.Errorf("Creating Cache Directory: %w", err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("Marshalling Cache: %w", err)
+	}
+
+	encData, err := client.EncryptMessage(string(data))
+	if err != nil {
+		return fmt.Errorf("Encrypting Cache: %w", err)
+	}
+
+	raw, err := json.Marshal(cacheFile{
+		Updated: time.Now(),
+		Data:    encData,
+	})
+	if err != nil {
+		return fmt.Errorf("Marshalling Cache File: %w", err)
+	}
+
+	// Write to a temporary file first so concurrent readers never see a partial file
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("Creating Cache File: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("Writing Cache File: %w", err)
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name+".json"))
+}
+
+// Load reads and decrypts the named Cache File into v and returns when it was saved.
+// A missing Cache File returns an error wrapping os.ErrNotExist.
+func Load(client *api.Client, name string, v any) (time.Time, error) {
+	dir, err := Dir()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return load(client, dir, name, v)
+}
+
+// LoadData reads and decrypts the named File in the Data Directory into v and returns when it was saved.
+// A missing File returns an error wrapping os.ErrNotExist.
+func LoadData(client *api.Client, name string, v any) (time.Time, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return load(client, dir, name, v)
+}
+
+func load(client *api.Client, dir, name string, v any) (time.Time, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Reading Cache File: %w", err)
+	}
+
+	var file cacheFile
+	err = json.Unmarshal(raw, &file)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Parsing Cache File: %w", err)
+	}
+
+	data, err := client.DecryptMessage(file.Data)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Decrypting Cache: %w", err)
+	}
+
+	err = json.Unmarshal([]byte(data), v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Parsing Cache: %w", err)
+	}
+	return file.Updated, nil
+}
+
+// Clear removes all Cache Files of the configured Server and User.
+func Clear() error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
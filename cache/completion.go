@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Completion is a single Shell Completion Candidate
+type Completion struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FetchCompletions fetches all Completion Candidates of one Entity type from the Server
+type FetchCompletions func(ctx context.Context, client *api.Client) ([]Completion, error)
+
+// CompletionFunc is the signature of cobra ValidArgsFunction and Flag Completion Functions
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// CompleteIDs returns a Completion Function for IDs of the given kind, with Names as Descriptions.
+// Candidates are served from the local encrypted Cache, the Server is only contacted once the Cache is older than completionCacheTTL.
+func CompleteIDs(kind string, fetch FetchCompletions) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completions, err := getCompletions(kind, fetch)
+		if err != nil {
+			cobra.CompDebugln(fmt.Sprintf("Getting %v Completions: %v", kind, err), false)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		result := []string{}
+		for _, c := range completions {
+			if strings.HasPrefix(c.ID, toComplete) {
+				result = append(result, c.ID+"\t"+strings.ReplaceAll(c.Name, "\t", " "))
+			}
+		}
+		return result, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CompleteArgIDs is the same as CompleteIDs but only completes the first Positional Argument
+func CompleteArgIDs(kind string, fetch FetchCompletions) CompletionFunc {
+	complete := CompleteIDs(kind, fetch)
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return complete(cmd, args, toComplete)
+	}
+}
+
+func getCompletions(kind string, fetch FetchCompletions) ([]Completion, error) {
+	// Unlocking the Key locally is enough to read the Cache, no Login needed
+	client, err := util.NewClient(false)
+	if err != nil {
+		return nil, err
+	}
+
+	name := "completion-" + kind
+	var cached []Completion
+	updated, loadErr := Load(client, name, &cached)
+	if loadErr == nil && time.Since(updated) < viper.GetDuration("completionCacheTTL") {
+		return cached, nil
+	}
+
+	// Logging in would prompt for MFA, better serve stale Data than nothing
+	if !util.CanLoginNoninteractive() {
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return cached, nil
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	apiClient, err := util.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, apiClient)
+
+	completions, err := fetch(ctx, apiClient)
+	if err != nil {
+		return nil, err
+	}
+
+	err = Save(client, name, completions)
+	if err != nil {
+		return nil, err
+	}
+	return completions, nil
+}
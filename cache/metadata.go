@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/spf13/viper"
+)
+
+// FetchMetadata fetches all Entities of one kind from the Server.
+// cached contains the previously cached Entities (possibly stale) so that unchanged Entities can be reused.
+type FetchMetadata[T any] func(ctx context.Context, client *api.Client, cached []T) ([]T, error)
+
+// Client is an unlocked Passbolt Client which only logs in once the Server actually needs to be contacted
+type Client struct {
+	*api.Client
+	loggedIn bool
+}
+
+// NewClient returns a Client which is not logged in yet.
+func NewClient() (*Client, error) {
+	client, err := util.NewClient(true)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Client: client}, nil
+}
+
+// FromClient wraps an already logged in Client.
+func FromClient(client *api.Client) *Client {
+	return &Client{Client: client, loggedIn: true}
+}
+
+// Login logs the Client in if that has not happened yet.
+func (c *Client) Login(ctx context.Context) error {
+	if c.loggedIn {
+		return nil
+	}
+	err := util.Login(ctx, c.Client)
+	if err != nil {
+		return err
+	}
+	c.loggedIn = true
+	return nil
+}
+
+// Logout saves pending Session Keys and logs out, but only if the Client was logged in.
+func (c *Client) Logout(ctx context.Context) {
+	if c.loggedIn {
+		util.SaveSessionKeysAndLogout(ctx, c.Client)
+		c.loggedIn = false
+	}
+}
+
+// Enabled reports if the Metadata Cache should be used (--cache)
+func Enabled() bool {
+	return viper.GetBool("cache")
+}
+
+// List returns all Entities of one kind using the Metadata Cache.
+// While the Cache is younger than --cacheTTL and --refresh is not set, the Server is not contacted at all.
+// Otherwise the Client is logged in, fetch is called with the old Cache content and the Cache is updated.
+func List[T any](ctx context.Context, client *Client, kind string, fetch FetchMetadata[T]) ([]T, error) {
+	var cached []T
+	updated, err := Load(client.Client, metadataName(kind), &cached)
+	if err != nil {
+		// A missing or unreadable Cache just means that everything has to be fetched
+		cached = nil
+		if viper.GetBool("debug") {
+			fmt.Fprintf(os.Stderr, "Loading %v Cache: %v\n", kind, err)
+		}
+	} else if !viper.GetBool("refresh") && time.Since(updated) < viper.GetDuration("cacheTTL") {
+		return cached, nil
+	}
+
+	err = client.Login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := fetch(ctx, client.Client, cached)
+	if err != nil {
+		return nil, err
+	}
+
+	// A failing Cache should never fail the actual Command
+	err = Save(client.Client, metadataName(kind), items)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update %v cache: %v\n", kind, err)
+	}
+	return items, nil
+}
+
+// LoadMetadata returns the cached Entities of one kind and when they were cached, without contacting the Server even if the Cache is stale.
+func LoadMetadata[T any](client *api.Client, kind string) ([]T, time.Time, error) {
+	var cached []T
+	updated, err := Load(client, metadataName(kind), &cached)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return cached, updated, nil
+}
+
+func metadataName(kind string) string {
+	return "metadata-" + kind
+}
@@ -0,0 +1,9 @@
+package cmd
+
+import (
+	"github.com/passbolt/go-passbolt-cli/manifest"
+)
+
+func init() {
+	rootCmd.AddCommand(manifest.ApplyCmd)
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/passbolt/go-passbolt-cli/resource"
+	"github.com/spf13/cobra"
+)
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audits Passbolt Entitys",
+	Long:  `Audits Passbolt Entitys`,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(resource.ResourceAuditCmd)
+}
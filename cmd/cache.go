@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manages the local Cache",
+	Long:  `Manages the local Cache of encrypted Metadata and Completions`,
+}
+
+// cacheClearCmd removes the local Cache
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Removes the local Cache of the configured Server and User",
+	Long: `Removes the local Cache of the configured Server and User.
+Snapshots and the local Resource History are stored separately and are kept.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := cache.Clear()
+		if err != nil {
+			return fmt.Errorf("Clearing Cache: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
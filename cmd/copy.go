@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/resource"
+	"github.com/spf13/cobra"
+)
+
+// copyCmd represents the copy command
+var copyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copies a Passbolt Entity",
+	Long:  `Copies a Passbolt Entity`,
+}
+
+func init() {
+	rootCmd.AddCommand(copyCmd)
+	copyCmd.AddCommand(resource.CopyResourceCmd)
+	copyCmd.AddCommand(folder.CopyFolderCmd)
+}
@@ -22,6 +22,4 @@ func init() {
 	deleteCmd.AddCommand(folder.FolderDeleteCmd)
 	deleteCmd.AddCommand(group.GroupDeleteCmd)
 	deleteCmd.AddCommand(user.UserDeleteCmd)
-
-	deleteCmd.PersistentFlags().String("id", "", "ID of the Entity to Delete")
 }
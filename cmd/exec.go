@@ -7,8 +7,8 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/passbolt/go-passbolt-cli/resource"
 	"github.com/passbolt/go-passbolt-cli/util"
-	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -43,17 +43,39 @@ func execAction(_ *cobra.Command, args []string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 	defer cancel()
 
-	client, err := util.GetClient(ctx)
-	if err != nil {
-		return fmt.Errorf("Creating client: %w", err)
-	}
+	var envVars []string
+	if viper.GetBool("offline") {
+		snapshot, _, err := resource.LoadSnapshot()
+		if err != nil {
+			return err
+		}
 
-	envVars, err := resolveEnvironmentSecrets(ctx, client)
-	if err != nil {
-		return fmt.Errorf("Resolving secrets: %w", err)
-	}
+		envVars, err = resolveEnvironmentSecrets(func(resourceId string) (string, error) {
+			r, err := snapshot.FindResource(resourceId)
+			if err != nil {
+				return "", err
+			}
+			return r.Password, nil
+		})
+		if err != nil {
+			return fmt.Errorf("Resolving secrets: %w", err)
+		}
+	} else {
+		client, err := util.GetClient(ctx)
+		if err != nil {
+			return fmt.Errorf("Creating client: %w", err)
+		}
+
+		envVars, err = resolveEnvironmentSecrets(func(resourceId string) (string, error) {
+			_, _, _, _, secret, _, err := helper.GetResource(ctx, client, resourceId)
+			return secret, err
+		})
+		if err != nil {
+			return fmt.Errorf("Resolving secrets: %w", err)
+		}
 
-	util.SaveSessionKeysAndLogout(ctx, client)
+		util.SaveSessionKeysAndLogout(ctx, client)
+	}
 
 	subCmd := exec.Command(args[0], args[1:]...)
 	subCmd.Stdin = os.Stdin
@@ -61,14 +83,15 @@ func execAction(_ *cobra.Command, args []string) error {
 	subCmd.Stderr = os.Stderr
 	subCmd.Env = envVars
 
-	if err = subCmd.Run(); err != nil {
+	if err := subCmd.Run(); err != nil {
 		return fmt.Errorf("Running command: %w", err)
 	}
 
 	return nil
 }
 
-func resolveEnvironmentSecrets(ctx context.Context, client *api.Client) ([]string, error) {
+// resolveEnvironmentSecrets replaces passbolt:// references using getSecret, which returns the Secret of a Resource ID
+func resolveEnvironmentSecrets(getSecret func(resourceId string) (string, error)) ([]string, error) {
 	envVars := os.Environ()
 
 	for i, envVar := range envVars {
@@ -85,7 +108,7 @@ func resolveEnvironmentSecrets(ctx context.Context, client *api.Client) ([]strin
 		}
 
 		resourceId := strings.TrimPrefix(value, PassboltPrefix)
-		_, _, _, _, secret, _, err := helper.GetResource(ctx, client, resourceId)
+		secret, err := getSecret(resourceId)
 		if err != nil {
 			return nil, fmt.Errorf("Getting resource: %w", err)
 		}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/spf13/cobra"
+)
+
+// folderCmd represents the folder command
+var folderCmd = &cobra.Command{
+	Use:   "folder",
+	Short: "Manages Passbolt Folders",
+	Long:  `Manages Passbolt Folders`,
+}
+
+func init() {
+	rootCmd.AddCommand(folderCmd)
+	folderCmd.AddCommand(folder.FolderSyncPermissionsCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/spf13/cobra"
+)
+
+// generateCmd represents the generate command
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generates a Password",
+	Long: `Generates a random Password or Passphrase.
+Use --serverPolicy to generate it according to the Password Policy of the Server, this requires logging in.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		serverPolicy, err := cmd.Flags().GetBool("serverPolicy")
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := util.GetContext()
+		defer cancel()
+
+		var client *api.Client
+		if serverPolicy {
+			client, err = util.GetClient(ctx)
+			if err != nil {
+				return err
+			}
+			defer util.SaveSessionKeysAndLogout(ctx, client)
+		}
+		cmd.SilenceUsage = true
+
+		policy, err := util.GetGeneratorPolicy(ctx, cmd, client)
+		if err != nil {
+			return err
+		}
+		password, err := util.GeneratePassword(policy)
+		if err != nil {
+			return fmt.Errorf("Generating Password: %w", err)
+		}
+		fmt.Println(password)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(generateCmd)
+	generateCmd.Flags().Bool("serverPolicy", false, "Use the Password Policy of the Server as Default")
+	util.AddGeneratorFlags(generateCmd)
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/passbolt/go-passbolt-cli/resource"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Shows the Change History of a Passbolt Entity",
+	Long:  `Shows the Change History of a Passbolt Entity`,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(resource.ResourceHistoryCmd)
+}
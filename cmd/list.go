@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/passbolt/go-passbolt-cli/actionlog"
 	"github.com/passbolt/go-passbolt-cli/folder"
 	"github.com/passbolt/go-passbolt-cli/group"
 	"github.com/passbolt/go-passbolt-cli/resource"
@@ -20,13 +21,22 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.PersistentFlags().BoolP("json", "j", false, "Output JSON")
 	listCmd.PersistentFlags().String("filter", "",
-		"Define a CEl expression as filter for any list commands. In the expression, all available columns of subcommand can be used (see -c/--column).\n"+
-			"See also CEl specifications under https://github.com/google/cel-spec.\n"+
+		"Define a CEL expression as filter for any list commands. In the expression, all available columns of subcommand can be used (see -c/--column).\n"+
+			"See also CEL specifications under https://github.com/google/cel-spec.\n"+
 			"Examples:\n"+
 			"\t--filter '(Name == \"SomeName\" || matches(Name, \"RegExpr\")) && URI.startsWith(\"https://auth.\")'\n"+
 			"\t--filter 'Username == \"User\" && CreatedTimestamp > timestamp(\"2022-06-10T00:00:00.000-00:00\")'")
+	listCmd.PersistentFlags().StringArray("sort", []string{},
+		"Sort by a column, append :desc for descending order. Can be specified multiple times, earlier keys take precedence.\n"+
+			"Instead of a column a CEL expression using the same variables as --filter can be used to sort by computed values.\n"+
+			"Examples:\n"+
+			"\t--sort Name --sort ModifiedTimestamp:desc\n"+
+			"\t--sort 'size(Password):desc'")
+	listCmd.PersistentFlags().Int("limit", 0, "Maximum number of entries to return, 0 returns all entries")
+	listCmd.PersistentFlags().Int("offset", 0, "Number of entries to skip before returning entries, applied after sorting")
 	listCmd.AddCommand(resource.ResourceListCmd)
 	listCmd.AddCommand(folder.FolderListCmd)
 	listCmd.AddCommand(group.GroupListCmd)
 	listCmd.AddCommand(user.UserListCmd)
+	listCmd.AddCommand(actionlog.ActionLogListCmd)
 }
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/passbolt/go-passbolt-cli/user"
+	"github.com/spf13/cobra"
+)
+
+// offboardCmd represents the offboard command
+var offboardCmd = &cobra.Command{
+	Use:   "offboard",
+	Short: "Offboards a Passbolt Entity",
+	Long:  `Offboards a Passbolt Entity`,
+}
+
+func init() {
+	rootCmd.AddCommand(offboardCmd)
+	offboardCmd.AddCommand(user.UserOffboardCmd)
+}
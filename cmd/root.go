@@ -67,6 +67,11 @@ func init() {
 	rootCmd.PersistentFlags().String("tlsClientCert", "", "Client certificate for mtls")
 
 	rootCmd.PersistentFlags().Uint("workers", 0, "Number of Concurrent Workers for Expensive Operations. 0 (default) uses the number of CPU cores")
+	rootCmd.PersistentFlags().Duration("completionCacheTTL", time.Minute*5, "How long IDs and Names cached for Shell Completion are used before refreshing them from the Server")
+	rootCmd.PersistentFlags().Bool("cache", false, "Use the local encrypted Metadata Cache for listing and resolving Names")
+	rootCmd.PersistentFlags().Bool("refresh", false, "Refresh the Metadata Cache from the Server even if it is not expired yet")
+	rootCmd.PersistentFlags().Duration("cacheTTL", time.Minute*10, "How long the Metadata Cache is used before refreshing it from the Server")
+	rootCmd.PersistentFlags().Bool("offline", false, "Serve Data read-only from the Snapshot stored by \"snapshot sync\" without contacting the Server")
 
 	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
 	viper.BindPFlag("timeout", rootCmd.PersistentFlags().Lookup("timeout"))
@@ -86,6 +91,11 @@ func init() {
 	viper.BindPFlag("tlsClientPrivateKey", rootCmd.PersistentFlags().Lookup("tlsClientPrivateKey"))
 
 	viper.BindPFlag("workers", rootCmd.PersistentFlags().Lookup("workers"))
+	viper.BindPFlag("completionCacheTTL", rootCmd.PersistentFlags().Lookup("completionCacheTTL"))
+	viper.BindPFlag("cache", rootCmd.PersistentFlags().Lookup("cache"))
+	viper.BindPFlag("refresh", rootCmd.PersistentFlags().Lookup("refresh"))
+	viper.BindPFlag("cacheTTL", rootCmd.PersistentFlags().Lookup("cacheTTL"))
+	viper.BindPFlag("offline", rootCmd.PersistentFlags().Lookup("offline"))
 }
 
 func fileToContent(file, contentFlag string) {
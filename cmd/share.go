@@ -17,4 +17,5 @@ func init() {
 	rootCmd.AddCommand(shareCmd)
 	shareCmd.AddCommand(resource.ResourceShareCmd)
 	shareCmd.AddCommand(folder.FolderShareCmd)
+	shareCmd.AddCommand(resource.ShareCopyCmd)
 }
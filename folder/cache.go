@@ -0,0 +1,29 @@
+package folder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// CacheKind is the Name of the Folder Metadata Cache
+const CacheKind = "folders"
+
+// FetchFolders fetches all Folders for the Metadata Cache, Folder Metadata is not encrypted so nothing is reused.
+func FetchFolders(ctx context.Context, client *api.Client, _ []api.Folder) ([]api.Folder, error) {
+	folders, err := client.GetFolders(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Folders: %w", err)
+	}
+	return folders, nil
+}
+
+// GetFolders returns all Folders, from the Metadata Cache if it is enabled.
+func GetFolders(ctx context.Context, client *api.Client) ([]api.Folder, error) {
+	if cache.Enabled() {
+		return cache.List(ctx, cache.FromClient(client), CacheKind, FetchFolders)
+	}
+	return FetchFolders(ctx, client, nil)
+}
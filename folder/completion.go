@@ -0,0 +1,31 @@
+package folder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// CompleteFolderIDs completes Folder IDs with their Paths as Description
+var CompleteFolderIDs = cache.CompleteIDs("folder", fetchFolderCompletions)
+
+var completeFolderArg = cache.CompleteArgIDs("folder", fetchFolderCompletions)
+
+func fetchFolderCompletions(ctx context.Context, client *api.Client) ([]cache.Completion, error) {
+	folders, err := client.GetFolders(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Folders: %w", err)
+	}
+
+	paths := GetFolderPaths(folders)
+	completions := make([]cache.Completion, len(folders))
+	for i, folder := range folders {
+		completions[i] = cache.Completion{
+			ID:   folder.ID,
+			Name: paths[folder.ID],
+		}
+	}
+	return completions, nil
+}
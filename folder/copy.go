@@ -0,0 +1,115 @@
+package folder
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+	"github.com/spf13/cobra"
+)
+
+// CopyFolderCmd Copies a Passbolt Folder with its Subfolders and Resources
+var CopyFolderCmd = &cobra.Command{
+	Use:   "folder [id]",
+	Short: "Copies a Passbolt Folder with its Subfolders and Resources",
+	Long: `Copies a Passbolt Folder with all of its Subfolders and Resources into another Folder and Returns the new Folders ID.
+The Copies are created with default Permissions (you are the Owner), Permissions of the Originals are not copied.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: CompleteFolderIDs,
+	RunE:              CopyFolder,
+}
+
+func init() {
+	CopyFolderCmd.Flags().String("id", "", "id, name or path of Folder to Copy")
+	CopyFolderCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to create the Copy, as id or path (e.g. Infra/AWS), Root if empty")
+	CopyFolderCmd.Flags().StringP("name", "n", "", "Name of the Copy, defaults to the Name of the Original")
+	CopyFolderCmd.RegisterFlagCompletionFunc("id", CompleteFolderIDs)
+	CopyFolderCmd.RegisterFlagCompletionFunc("folderParentID", CompleteFolderIDs)
+}
+
+func CopyFolder(cmd *cobra.Command, args []string) error {
+	folderID, err := util.GetIDArg(cmd, args)
+	if err != nil {
+		return err
+	}
+	folderParentID, err := cmd.Flags().GetString("folderParentID")
+	if err != nil {
+		return err
+	}
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	folders, err := client.GetFolders(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Listing Folders: %w", err)
+	}
+	folderID, err = ResolveFolderIDFromList(folders, folderID)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+	if folderParentID != "" {
+		folderParentID, err = ResolveFolderIDFromList(folders, folderParentID)
+		if err != nil {
+			return fmt.Errorf("Resolving Destination Folder: %w", err)
+		}
+	}
+
+	ids := SubfolderIDs(folders, folderID)
+	if slices.Contains(ids, folderParentID) {
+		return fmt.Errorf("Can't Copy a Folder into itself")
+	}
+
+	// Create Folders Parents first, mapping the Original IDs to the Copies
+	byID := make(map[string]api.Folder, len(folders))
+	for _, f := range folders {
+		byID[f.ID] = f
+	}
+	newIDs := map[string]string{}
+	for _, id := range ids {
+		parent, folderName := newIDs[byID[id].FolderParentID], byID[id].Name
+		if id == folderID {
+			parent = folderParentID
+			if name != "" {
+				folderName = name
+			}
+		}
+		newID, err := helper.CreateFolder(ctx, client, parent, folderName)
+		if err != nil {
+			return fmt.Errorf("Creating Folder %v: %w", folderName, err)
+		}
+		newIDs[id] = newID
+	}
+
+	resources, err := client.GetResources(ctx, &api.GetResourcesOptions{
+		FilterHasParent: ids,
+		ContainSecret:   true,
+	})
+	if err != nil {
+		return fmt.Errorf("Listing Resources: %w", err)
+	}
+
+	for _, r := range resources {
+		_, err := util.CopyResource(ctx, client, r, newIDs[r.FolderParentID], "")
+		if err != nil {
+			return fmt.Errorf("Copying Resource %v: %w", r.ID, err)
+		}
+	}
+
+	fmt.Printf("Copied %d Folders and %d Resources\n", len(ids), len(resources))
+	fmt.Printf("FolderID: %v\n", newIDs[folderID])
+	return nil
+}
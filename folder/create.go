@@ -19,7 +19,8 @@ var FolderCreateCmd = &cobra.Command{
 
 func init() {
 	FolderCreateCmd.Flags().StringP("name", "n", "", "Folder Name")
-	FolderCreateCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to create the Folder")
+	FolderCreateCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to create the Folder, as id or path (e.g. Infra/AWS)")
+	FolderCreateCmd.RegisterFlagCompletionFunc("folderParentID", CompleteFolderIDs)
 
 	FolderCreateCmd.MarkFlagRequired("name")
 }
@@ -48,6 +49,11 @@ func FolderCreate(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	folderParentID, err = ResolveFolderID(ctx, client, folderParentID)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
 	id, err := helper.CreateFolder(
 		ctx,
 		client,
@@ -1,28 +1,48 @@
 package folder
 
 import (
+	"context"
 	"fmt"
+	"slices"
 
+	"al.essio.dev/pkg/shellescape"
 	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
 // FolderDeleteCmd Deletes a Folder
 var FolderDeleteCmd = &cobra.Command{
-	Use:   "folder",
+	Use:   "folder [id]",
 	Short: "Deletes a Passbolt Folder",
-	Long:  `Deletes a Passbolt Folder`,
-	RunE:  FolderDelete,
+	Long: `Deletes a Passbolt Folder.
+With --recursive all Subfolders and Resources inside the Folder are deleted too,
+after showing a Preview and asking for Confirmation (skip with --yes).`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFolderArg,
+	RunE:              FolderDelete,
+}
+
+func init() {
+	FolderDeleteCmd.Flags().String("id", "", "id, name or path of Folder to Delete")
+	FolderDeleteCmd.Flags().BoolP("recursive", "r", false, "Also Delete all Subfolders and Resources inside the Folder")
+	FolderDeleteCmd.Flags().BoolP("yes", "y", false, "Don't ask for Confirmation before a recursive Delete")
+	FolderDeleteCmd.RegisterFlagCompletionFunc("id", CompleteFolderIDs)
 }
 
 func FolderDelete(cmd *cobra.Command, args []string) error {
-	folderID, err := cmd.Flags().GetString("id")
+	folderID, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
-
-	if folderID == "" {
-		return fmt.Errorf("No ID to Delete Provided")
+	recursive, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
 	}
 
 	ctx, cancel := util.GetContext()
@@ -35,9 +55,70 @@ func FolderDelete(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	folderID, err = ResolveFolderID(ctx, client, folderID)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
+	if recursive {
+		return deleteFolderRecursive(ctx, client, folderID, yes)
+	}
+
 	err = client.DeleteFolder(ctx, folderID)
 	if err != nil {
 		return fmt.Errorf("Deleting Folder: %w", err)
 	}
 	return nil
 }
+
+// deleteFolderRecursive Deletes a Folder with all of its Subfolders and Resources
+func deleteFolderRecursive(ctx context.Context, client *api.Client, folderID string, yes bool) error {
+	folders, err := client.GetFolders(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Listing Folders: %w", err)
+	}
+	ids := SubfolderIDs(folders, folderID)
+
+	resources, err := client.GetResources(ctx, &api.GetResourcesOptions{
+		FilterHasParent: ids,
+	})
+	if err != nil {
+		return fmt.Errorf("Listing Resources: %w", err)
+	}
+
+	counts := map[string]int{}
+	for _, r := range resources {
+		counts[r.FolderParentID]++
+	}
+	paths := GetFolderPaths(folders)
+	data := pterm.TableData{{"Folder", "Resources"}}
+	for _, id := range ids {
+		data = append(data, []string{shellescape.StripUnsafe(paths[id]), fmt.Sprint(counts[id])})
+	}
+	err = pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	if err != nil {
+		return err
+	}
+
+	err = util.Confirm(fmt.Sprintf("Delete %d Folders and %d Resources?", len(ids), len(resources)), yes)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range resources {
+		err = client.DeleteResource(ctx, r.ID)
+		if err != nil {
+			return fmt.Errorf("Deleting Resource %v: %w", r.ID, err)
+		}
+	}
+
+	// Delete Children before their Parents
+	slices.Reverse(ids)
+	for _, id := range ids {
+		err = client.DeleteFolder(ctx, id)
+		if err != nil {
+			return fmt.Errorf("Deleting Folder %v: %w", id, err)
+		}
+	}
+	return nil
+}
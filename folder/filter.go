@@ -9,7 +9,7 @@ import (
 	"github.com/passbolt/go-passbolt/api"
 )
 
-// Environments for CEl
+// Environments for CEL
 var celEnvOptions = []cel.EnvOption{
 	cel.Variable("ID", cel.StringType),
 	cel.Variable("FolderParentID", cel.StringType),
@@ -18,6 +18,17 @@ var celEnvOptions = []cel.EnvOption{
 	cel.Variable("ModifiedTimestamp", cel.TimestampType),
 }
 
+// folderCelVars returns the CEL activation for a folder
+func folderCelVars(folder api.Folder) map[string]any {
+	return map[string]any{
+		"ID":                folder.ID,
+		"FolderParentID":    folder.FolderParentID,
+		"Name":              folder.Name,
+		"CreatedTimestamp":  folder.Created.Time,
+		"ModifiedTimestamp": folder.Modified.Time,
+	}
+}
+
 // Filters the slice folders by invoke CEL program for each folder
 func filterFolders(folders *[]api.Folder, celCmd string, ctx context.Context) ([]api.Folder, error) {
 	if celCmd == "" {
@@ -31,13 +42,7 @@ func filterFolders(folders *[]api.Folder, celCmd string, ctx context.Context) ([
 
 	filteredFolders := []api.Folder{}
 	for _, folder := range *folders {
-		val, _, err := (*program).ContextEval(ctx, map[string]any{
-			"ID":                folder.ID,
-			"FolderParentID":    folder.FolderParentID,
-			"Name":              folder.Name,
-			"CreatedTimestamp":  folder.Created.Time,
-			"ModifiedTimestamp": folder.Modified.Time,
-		})
+		val, _, err := (*program).ContextEval(ctx, folderCelVars(folder))
 
 		if err != nil {
 			return nil, err
@@ -3,48 +3,49 @@ package folder
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
-	"time"
 
 	"al.essio.dev/pkg/shellescape"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/api"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 )
 
 // FolderGetCmd Gets a Passbolt Folder
 var FolderGetCmd = &cobra.Command{
-	Use:   "folder",
-	Short: "Gets a Passbolt Folder",
-	Long:  `Gets a Passbolt Folder`,
-	RunE:  FolderGet,
+	Use:               "folder [id]",
+	Short:             "Gets a Passbolt Folder",
+	Long:              `Gets a Passbolt Folder`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFolderArg,
+	RunE:              FolderGet,
 }
 
 // FolderPermissionCmd Gets Permissions for Passbolt Folder
 var FolderPermissionCmd = &cobra.Command{
-	Use:     "permission",
-	Short:   "Gets Permissions for a Passbolt Folder",
-	Long:    `Gets Permissions for a Passbolt Folder`,
-	Aliases: []string{"permissions"},
-	RunE:    FolderPermission,
+	Use:               "permission [id]",
+	Short:             "Gets Permissions for a Passbolt Folder",
+	Long:              `Gets Permissions for a Passbolt Folder`,
+	Aliases:           []string{"permissions"},
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFolderArg,
+	RunE:              FolderPermission,
 }
 
 func init() {
-	FolderGetCmd.Flags().String("id", "", "id of Folder to Get")
+	FolderGetCmd.Flags().String("id", "", "id, name or path of Folder to Get")
 
-	FolderGetCmd.MarkFlagRequired("id")
+	FolderGetCmd.RegisterFlagCompletionFunc("id", CompleteFolderIDs)
 
 	FolderGetCmd.AddCommand(FolderPermissionCmd)
-	FolderPermissionCmd.Flags().String("id", "", "id of Folder to get permissions for")
-	FolderPermissionCmd.Flags().StringArrayP("column", "c", []string{"ID", "Aco", "AcoForeignKey", "Aro", "AroForeignKey", "Type"}, "Columns to return, possible Columns:\nID, Aco, AcoForeignKey, Aro, AroForeignKey, Type, CreatedTimestamp, ModifiedTimestamp")
+	FolderPermissionCmd.Flags().String("id", "", "id, name or path of Folder to get permissions for")
+	FolderPermissionCmd.Flags().StringArrayP("column", "c", util.DefaultPermissionColumns, util.PermissionColumnsHelp)
+	FolderPermissionCmd.Flags().String("filter", "", util.PermissionFilterHelp)
 
-	FolderPermissionCmd.MarkFlagRequired("id")
+	FolderPermissionCmd.RegisterFlagCompletionFunc("id", CompleteFolderIDs)
 }
 
 func FolderGet(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -63,6 +64,11 @@ func FolderGet(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	id, err = ResolveFolderID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
 	folder, err := client.GetFolder(ctx, id, nil)
 	if err != nil {
 		return fmt.Errorf("Getting Folder: %w", err)
@@ -84,7 +90,7 @@ func FolderGet(cmd *cobra.Command, args []string) error {
 }
 
 func FolderPermission(cmd *cobra.Command, args []string) error {
-	folderID, err := cmd.Flags().GetString("id")
+	folderID, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -99,6 +105,10 @@ func FolderPermission(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	filter, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		return err
+	}
 
 	ctx, cancel := util.GetContext()
 	defer cancel()
@@ -110,6 +120,11 @@ func FolderPermission(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	folderID, err = ResolveFolderID(ctx, client, folderID)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
 	folder, err := client.GetFolder(ctx, folderID, &api.GetFolderOptions{
 		ContainPermissions: true,
 	})
@@ -119,58 +134,5 @@ func FolderPermission(cmd *cobra.Command, args []string) error {
 
 	permissions := folder.Permissions
 
-	if jsonOutput {
-		outputPermissions := []util.PermissionJsonOutput{}
-		for i := range permissions {
-			outputPermissions = append(outputPermissions, util.PermissionJsonOutput{
-				ID:                &permissions[i].ID,
-				Aco:               &permissions[i].ACO,
-				AcoForeignKey:     &permissions[i].ACOForeignKey,
-				Aro:               &permissions[i].ARO,
-				AroForeignKey:     &permissions[i].AROForeignKey,
-				Type:              &permissions[i].Type,
-				CreatedTimestamp:  &permissions[i].Created.Time,
-				ModifiedTimestamp: &permissions[i].Modified.Time,
-			})
-		}
-		jsonPermissions, err := json.MarshalIndent(outputPermissions, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(jsonPermissions))
-	} else {
-		data := pterm.TableData{columns}
-
-		for _, permission := range permissions {
-			entry := make([]string, len(columns))
-			for i := range columns {
-				switch strings.ToLower(columns[i]) {
-				case "id":
-					entry[i] = permission.ID
-				case "aco":
-					entry[i] = permission.ACO
-				case "acoforeignkey":
-					entry[i] = permission.ACOForeignKey
-				case "aro":
-					entry[i] = permission.ARO
-				case "aroforeignkey":
-					entry[i] = permission.AROForeignKey
-				case "type":
-					entry[i] = strconv.Itoa(permission.Type)
-				case "createdtimestamp":
-					entry[i] = permission.Created.Format(time.RFC3339)
-				case "modifiedtimestamp":
-					entry[i] = permission.Modified.Format(time.RFC3339)
-				default:
-					cmd.SilenceUsage = false
-					return fmt.Errorf("Unknown Column: %v", columns[i])
-				}
-			}
-			data = append(data, entry)
-		}
-
-		pterm.DefaultTable.WithHasHeader().WithData(data).Render()
-	}
-
-	return nil
+	return util.PrintPermissions(ctx, client, permissions, columns, jsonOutput, filter)
 }
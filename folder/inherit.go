@@ -0,0 +1,153 @@
+package folder
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/spf13/cobra"
+)
+
+// FolderSyncPermissionsCmd Applies the Permissions of a Folder to everything in it
+var FolderSyncPermissionsCmd = &cobra.Command{
+	Use:   "sync-permissions [id]",
+	Short: "Applies the Permissions of a Folder to everything in it",
+	Long: `Gives all Folders and Resources inside a Folder (recursively) exactly the same Permissions as the Folder itself.
+The Changes are shown and need to be confirmed before applying, --dryRun only shows them.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: CompleteFolderIDs,
+	RunE:              FolderSyncPermissions,
+}
+
+func init() {
+	FolderSyncPermissionsCmd.Flags().String("id", "", "id or path of Folder whose Permissions to apply")
+	FolderSyncPermissionsCmd.Flags().Bool("dryRun", false, "Only show the Changes without applying them")
+	FolderSyncPermissionsCmd.Flags().BoolP("yes", "y", false, "Don't ask for Confirmation before applying")
+	FolderSyncPermissionsCmd.RegisterFlagCompletionFunc("id", CompleteFolderIDs)
+}
+
+func FolderSyncPermissions(cmd *cobra.Command, args []string) error {
+	id, err := util.GetIDArg(cmd, args)
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool("dryRun")
+	if err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	id, err = ResolveFolderID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
+	folders, err := getFoldersWithPermissions(ctx, client)
+	if err != nil {
+		return err
+	}
+	source, err := findFolder(folders, id)
+	if err != nil {
+		return err
+	}
+
+	targets, err := getFolderTreeTargets(ctx, client, folders, id, false)
+	if err != nil {
+		return err
+	}
+	return util.InheritPermissions(ctx, client, util.ShareSpecFromPermissions(source.Permissions, false), targets, dryRun, yes, nil)
+}
+
+// inheritFolderPermissions gives a Folder moved into parentID and everything in it the Permissions of parentID,
+// move is called after the Changes are confirmed and before any Permission is changed.
+func inheritFolderPermissions(ctx context.Context, client *api.Client, folderID, parentID string, yes bool, move func() error) error {
+	folders, err := getFoldersWithPermissions(ctx, client)
+	if err != nil {
+		return err
+	}
+	parent, err := findFolder(folders, parentID)
+	if err != nil {
+		return err
+	}
+
+	targets, err := getFolderTreeTargets(ctx, client, folders, folderID, true)
+	if err != nil {
+		return err
+	}
+	return util.InheritPermissions(ctx, client, util.ShareSpecFromPermissions(parent.Permissions, false), targets, false, yes, move)
+}
+
+func getFoldersWithPermissions(ctx context.Context, client *api.Client) ([]api.Folder, error) {
+	folders, err := client.GetFolders(ctx, &api.GetFoldersOptions{
+		ContainPermissions: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Listing Folders: %w", err)
+	}
+	return folders, nil
+}
+
+func findFolder(folders []api.Folder, id string) (*api.Folder, error) {
+	for i := range folders {
+		if folders[i].ID == id {
+			return &folders[i], nil
+		}
+	}
+	return nil, fmt.Errorf("Folder %v not found", id)
+}
+
+// getFolderTreeTargets returns all Folders and Resources inside a Folder (recursively) with their current Permissions
+func getFolderTreeTargets(ctx context.Context, client *api.Client, folders []api.Folder, rootID string, includeRoot bool) ([]*util.ShareTarget, error) {
+	paths := GetFolderPaths(folders)
+	ids := SubfolderIDs(folders, rootID)
+
+	targets := []*util.ShareTarget{}
+	for _, f := range folders {
+		if !slices.Contains(ids, f.ID) || (f.ID == rootID && !includeRoot) {
+			continue
+		}
+		targets = append(targets, &util.ShareTarget{Kind: "Folder", ID: f.ID, Name: paths[f.ID], Current: f.Permissions})
+	}
+
+	resources, err := client.GetResources(ctx, &api.GetResourcesOptions{
+		FilterHasParent: ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Listing Resources: %w", err)
+	}
+	for _, r := range resources {
+		name, err := util.ResourceName(ctx, client, r)
+		if err != nil {
+			return nil, err
+		}
+		if parent, ok := paths[r.FolderParentID]; ok {
+			name = parent + PathSeparator + name
+		}
+		current, err := client.GetResourcePermissions(ctx, r.ID)
+		if err != nil {
+			return nil, fmt.Errorf("Getting Permissions of %v: %w", name, err)
+		}
+		targets = append(targets, &util.ShareTarget{
+			Kind:    "Resource",
+			ID:      r.ID,
+			Name:    name,
+			Current: current,
+		})
+	}
+	return targets, nil
+}
@@ -1,12 +1,15 @@
 package folder
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/cache"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/api"
 	"github.com/spf13/cobra"
@@ -28,8 +31,10 @@ var FolderListCmd = &cobra.Command{
 func init() {
 	flags := FolderListCmd.Flags()
 	flags.StringP("search", "s", "", "Folders that have this in the Name")
-	flags.StringArrayP("folder", "f", []string{}, "Folders that are in this Folder")
+	flags.StringArrayP("folder", "f", []string{}, "Folders that are in this Folder, as id or path (e.g. Infra/AWS)")
 	flags.StringArrayP("group", "g", []string{}, "Folders that are shared with group")
+	flags.Bool("tree", false, "Show the Folder Hierarchy as Tree with the Number of Resources in each Folder")
+	FolderListCmd.RegisterFlagCompletionFunc("folder", CompleteFolderIDs)
 	flags.StringArrayP("column", "c", defaultTableColumns, "Columns to return (default list only for table format; JSON format includes all fields by default).\nPossible Columns: ID, FolderParentID, Name, CreatedTimestamp, ModifiedTimestamp")
 }
 
@@ -40,6 +45,10 @@ type folderListConfig struct {
 	columnsChanged bool
 	jsonOutput     bool
 	celFilter      string
+	sortKeys       []util.SortKey
+	limit          int
+	offset         int
+	tree           bool
 }
 
 func FolderList(cmd *cobra.Command, args []string) error {
@@ -51,26 +60,74 @@ func FolderList(cmd *cobra.Command, args []string) error {
 	ctx, cancel := util.GetContext()
 	defer cancel()
 
-	client, err := util.GetClient(ctx)
-	if err != nil {
-		return err
+	var folders []api.Folder
+	var resources []api.Resource
+	if cache.Enabled() {
+		client, err := cache.NewClient()
+		if err != nil {
+			return err
+		}
+		defer client.Logout(ctx)
+		cmd.SilenceUsage = true
+
+		folders, err = listCachedFolders(ctx, client, config)
+		if err != nil {
+			return err
+		}
+
+		if config.tree {
+			err = client.Login(ctx)
+			if err != nil {
+				return err
+			}
+			resources, err = client.GetResources(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("Listing Resources: %w", err)
+			}
+		}
+	} else {
+		client, err := util.GetClient(ctx)
+		if err != nil {
+			return err
+		}
+		defer util.SaveSessionKeysAndLogout(ctx, client)
+		cmd.SilenceUsage = true
+
+		config.parentFolders, err = ResolveFolderIDs(ctx, client, config.parentFolders)
+		if err != nil {
+			return fmt.Errorf("Resolving Folders: %w", err)
+		}
+
+		folders, err = client.GetFolders(ctx, &api.GetFoldersOptions{
+			FilterHasParent: config.parentFolders,
+			FilterSearch:    config.search,
+		})
+		if err != nil {
+			return fmt.Errorf("Listing Folder: %w", err)
+		}
+
+		if config.tree {
+			resources, err = client.GetResources(ctx, nil)
+			if err != nil {
+				return fmt.Errorf("Listing Resources: %w", err)
+			}
+		}
 	}
-	defer util.SaveSessionKeysAndLogout(ctx, client)
-	cmd.SilenceUsage = true
 
-	folders, err := client.GetFolders(ctx, &api.GetFoldersOptions{
-		FilterHasParent: config.parentFolders,
-		FilterSearch:    config.search,
-	})
+	folders, err = filterFolders(&folders, config.celFilter, ctx)
 	if err != nil {
-		return fmt.Errorf("Listing Folder: %w", err)
+		return err
 	}
 
-	folders, err = filterFolders(&folders, config.celFilter, ctx)
+	err = util.SortByKeys(ctx, folders, config.sortKeys, folderCelVars, celEnvOptions...)
 	if err != nil {
 		return err
 	}
+	folders = util.Paginate(folders, config.offset, config.limit)
 
+	if config.tree {
+		return printTreeFolders(folders, resources)
+	}
 	if config.jsonOutput {
 		return printJsonFolders(folders, config.columnsChanged, config.columns)
 	}
@@ -78,6 +135,33 @@ func FolderList(cmd *cobra.Command, args []string) error {
 	return printTableFolders(config.columns, folders)
 }
 
+// listCachedFolders applies the Server side Filters locally on the cached Folders
+func listCachedFolders(ctx context.Context, client *cache.Client, config *folderListConfig) ([]api.Folder, error) {
+	folders, err := cache.List(ctx, client, CacheKind, FetchFolders)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, parent := range config.parentFolders {
+		config.parentFolders[i], err = ResolveFolderIDFromList(folders, parent)
+		if err != nil {
+			return nil, fmt.Errorf("Resolving Folders: %w", err)
+		}
+	}
+
+	filtered := []api.Folder{}
+	for _, folder := range folders {
+		if len(config.parentFolders) != 0 && !slices.Contains(config.parentFolders, folder.FolderParentID) {
+			continue
+		}
+		if config.search != "" && !strings.Contains(strings.ToLower(folder.Name), strings.ToLower(config.search)) {
+			continue
+		}
+		filtered = append(filtered, folder)
+	}
+	return filtered, nil
+}
+
 func printJsonFolders(folders []api.Folder, isColumnsChanged bool, columns []string) error {
 	outputFolders := make([]FolderJsonOutput, len(folders))
 	for i := range folders {
@@ -176,6 +260,18 @@ func parseFolderListFlags(cmd *cobra.Command) (*folderListConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	sortKeys, limit, offset, err := util.GetSortAndPagination(cmd, celEnvOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := cmd.Flags().GetBool("tree")
+	if err != nil {
+		return nil, err
+	}
+	if tree && jsonOutput {
+		return nil, fmt.Errorf("--tree can't be combined with --json")
+	}
 
 	return &folderListConfig{
 		search:         search,
@@ -184,5 +280,9 @@ func parseFolderListFlags(cmd *cobra.Command) (*folderListConfig, error) {
 		columnsChanged: cmd.Flags().Changed("column"),
 		jsonOutput:     jsonOutput,
 		celFilter:      celFilter,
+		sortKeys:       sortKeys,
+		limit:          limit,
+		offset:         offset,
+		tree:           tree,
 	}, nil
 }
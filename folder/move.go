@@ -10,22 +10,27 @@ import (
 
 // FolderMoveCmd Moves a Passbolt Folder
 var FolderMoveCmd = &cobra.Command{
-	Use:   "folder",
-	Short: "Moves a Passbolt Folder into a Folder",
-	Long:  `Moves a Passbolt Folder into a Folder`,
-	RunE:  FolderMove,
+	Use:               "folder [id]",
+	Short:             "Moves a Passbolt Folder into a Folder",
+	Long:              `Moves a Passbolt Folder into a Folder`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFolderArg,
+	RunE:              FolderMove,
 }
 
 func init() {
-	FolderMoveCmd.Flags().String("id", "", "id of Folder to Move")
-	FolderMoveCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to Move the Folder")
+	FolderMoveCmd.Flags().String("id", "", "id, name or path of Folder to Move")
+	FolderMoveCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to Move the Folder, as id or path (e.g. Infra/AWS)")
+	FolderMoveCmd.Flags().Bool("inheritPermissions", false, "Give the Folder and everything in it the Permissions of the new Parent Folder")
+	FolderMoveCmd.Flags().BoolP("yes", "y", false, "Don't ask for Confirmation before changing Permissions")
+	FolderMoveCmd.RegisterFlagCompletionFunc("folderParentID", CompleteFolderIDs)
 
-	FolderMoveCmd.MarkFlagRequired("id")
+	FolderMoveCmd.RegisterFlagCompletionFunc("id", CompleteFolderIDs)
 	FolderMoveCmd.MarkFlagRequired("folderParentID")
 }
 
 func FolderMove(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -33,6 +38,14 @@ func FolderMove(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	inherit, err := cmd.Flags().GetBool("inheritPermissions")
+	if err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
 
 	ctx, cancel := util.GetContext()
 	defer cancel()
@@ -44,14 +57,34 @@ func FolderMove(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
-	err = helper.MoveFolder(
-		ctx,
-		client,
-		id,
-		folderParentID,
-	)
+	id, err = ResolveFolderID(ctx, client, id)
 	if err != nil {
-		return fmt.Errorf("Moving Folder: %w", err)
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
+	folderParentID, err = ResolveFolderID(ctx, client, folderParentID)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
+	move := func() error {
+		err := helper.MoveFolder(
+			ctx,
+			client,
+			id,
+			folderParentID,
+		)
+		if err != nil {
+			return fmt.Errorf("Moving Folder: %w", err)
+		}
+		return nil
+	}
+
+	if inherit {
+		if folderParentID == "" {
+			return fmt.Errorf("The Root has no Permissions to inherit")
+		}
+		return inheritFolderPermissions(ctx, client, id, folderParentID, yes, move)
 	}
-	return nil
+	return move()
 }
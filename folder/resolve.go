@@ -0,0 +1,122 @@
+package folder
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// PathSeparator separates Folder Names in a Folder Path like "Infra/AWS"
+const PathSeparator = "/"
+
+// ResolveFolderID returns the ID of the Folder referenced by an ID, Name or Path like "Infra/AWS".
+// An empty input stays empty as it references the root Folder.
+func ResolveFolderID(ctx context.Context, client *api.Client, input string) (string, error) {
+	if input == "" || util.IsUUID(input) {
+		return input, nil
+	}
+
+	folders, err := GetFolders(ctx, client)
+	if err != nil {
+		return "", err
+	}
+	return ResolveFolderIDFromList(folders, input)
+}
+
+// ResolveFolderIDs resolves multiple Folders at once, only fetching the Folder list if needed.
+func ResolveFolderIDs(ctx context.Context, client *api.Client, inputs []string) ([]string, error) {
+	var folders []api.Folder
+	ids := make([]string, len(inputs))
+	for i, input := range inputs {
+		if input == "" || util.IsUUID(input) {
+			ids[i] = input
+			continue
+		}
+
+		if folders == nil {
+			var err error
+			folders, err = GetFolders(ctx, client)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		id, err := ResolveFolderIDFromList(folders, input)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// ResolveFolderIDFromList is the same as ResolveFolderID but uses an already fetched list of Folders.
+func ResolveFolderIDFromList(folders []api.Folder, input string) (string, error) {
+	if input == "" || util.IsUUID(input) {
+		return input, nil
+	}
+
+	paths := GetFolderPaths(folders)
+	segments := strings.Split(strings.Trim(input, PathSeparator), PathSeparator)
+
+	candidates := []util.Candidate{}
+	for _, folder := range folders {
+		names := []string{paths[folder.ID]}
+		// A single Name may reference a Folder anywhere in the Tree
+		if len(segments) == 1 {
+			names = append(names, folder.Name)
+		}
+		candidates = append(candidates, util.Candidate{
+			ID:      folder.ID,
+			Names:   names,
+			Display: paths[folder.ID],
+		})
+	}
+	return util.ResolveCandidate("folder", strings.Join(segments, PathSeparator), candidates)
+}
+
+// GetFolderPaths returns the full Path of every Folder indexed by ID.
+// Folders whose Parent is not accessible are treated as top level Folders.
+func GetFolderPaths(folders []api.Folder) map[string]string {
+	byID := make(map[string]api.Folder, len(folders))
+	for _, folder := range folders {
+		byID[folder.ID] = folder
+	}
+
+	paths := make(map[string]string, len(folders))
+	var pathOf func(id string, depth int) string
+	pathOf = func(id string, depth int) string {
+		if path, ok := paths[id]; ok {
+			return path
+		}
+		folder := byID[id]
+		path := folder.Name
+		// the depth check protects against cycles in inconsistent data
+		if parent, ok := byID[folder.FolderParentID]; ok && depth < len(folders) {
+			path = pathOf(parent.ID, depth+1) + PathSeparator + folder.Name
+		}
+		paths[id] = path
+		return path
+	}
+
+	for _, folder := range folders {
+		pathOf(folder.ID, 0)
+	}
+	return paths
+}
+
+// SubfolderIDs returns the ID of the Folder and all of its Subfolders (recursively), Parents before their Children
+func SubfolderIDs(folders []api.Folder, id string) []string {
+	ids := []string{id}
+	for i := 0; i < len(ids); i++ {
+		for _, f := range folders {
+			if f.FolderParentID == ids[i] && !slices.Contains(ids, f.ID) {
+				ids = append(ids, f.ID)
+			}
+		}
+	}
+	return ids
+}
@@ -3,43 +3,48 @@ package folder
 import (
 	"fmt"
 
+	"github.com/passbolt/go-passbolt-cli/group"
+	"github.com/passbolt/go-passbolt-cli/user"
 	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
 	"github.com/spf13/cobra"
 )
 
 // FolderShareCmd Shares a Passbolt Folder
 var FolderShareCmd = &cobra.Command{
-	Use:   "folder",
+	Use:   "folder [id]",
 	Short: "Shares a Passbolt Folder",
-	Long:  `Shares a Passbolt Folder`,
-	RunE:  FolderShare,
+	Long: `Shares a Passbolt Folder.
+Users and Groups can be given with --user and --group and the Permission --type,
+or with their Permission Level using --read, --update and --owner (e.g. --owner jane@example.com --read group:Developers).
+--revoke removes the Permissions of --user and --group, --replace revokes all Permissions not given.
+The Permissions before and after the Change are shown before applying, --replace additionally asks for Confirmation.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFolderArg,
+	RunE:              FolderShare,
 }
 
 func init() {
-	FolderShareCmd.Flags().String("id", "", "id of Folder to Share")
-	FolderShareCmd.Flags().IntP("type", "t", 1, "Permission Type (1 Read Only, 7 Can Update, 15 Owner)")
-	FolderShareCmd.Flags().StringArrayP("user", "u", []string{}, "User id's to share with")
-	FolderShareCmd.Flags().StringArrayP("group", "g", []string{}, "Group id's to share with")
+	FolderShareCmd.Flags().String("id", "", "id, name or path of Folder to Share")
+	util.AddShareFlags(FolderShareCmd)
+	FolderShareCmd.Flags().BoolP("yes", "y", false, "Don't ask for Confirmation before replacing Permissions")
+	FolderShareCmd.RegisterFlagCompletionFunc("user", user.CompleteUserIDs)
+	FolderShareCmd.RegisterFlagCompletionFunc("group", group.CompleteGroupIDs)
 
-	FolderShareCmd.MarkFlagRequired("id")
-	FolderShareCmd.MarkFlagRequired("type")
+	FolderShareCmd.RegisterFlagCompletionFunc("id", CompleteFolderIDs)
 }
 
 func FolderShare(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
-	pType, err := cmd.Flags().GetInt("type")
+	spec, err := util.GetShareSpec(cmd)
 	if err != nil {
 		return err
 	}
-	users, err := cmd.Flags().GetStringArray("user")
-	if err != nil {
-		return err
-	}
-	groups, err := cmd.Flags().GetStringArray("group")
+	yes, err := cmd.Flags().GetBool("yes")
 	if err != nil {
 		return err
 	}
@@ -54,14 +59,39 @@ func FolderShare(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
-	err = helper.ShareFolderWithUsersAndGroups(
-		ctx,
-		client,
-		id,
-		users,
-		groups,
-		pType,
-	)
+	id, err = ResolveFolderID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
+	err = spec.Resolve(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	folder, err := client.GetFolder(ctx, id, &api.GetFolderOptions{
+		ContainPermissions: true,
+	})
+	if err != nil {
+		return fmt.Errorf("Getting Permissions: %w", err)
+	}
+
+	changes := spec.Changes(folder.Permissions)
+	err = util.PrintShareDiff(ctx, client, folder.Permissions, changes)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if spec.Replace {
+		err = util.Confirm("Replace the Permissions of this Folder?", yes)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = helper.ShareFolder(ctx, client, id, changes)
 	if err != nil {
 		return fmt.Errorf("Sharing Folder: %w", err)
 	}
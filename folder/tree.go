@@ -0,0 +1,57 @@
+package folder
+
+import (
+	"fmt"
+	"sort"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/pterm/pterm"
+)
+
+// printTreeFolders renders the Folder Hierarchy with the Number of Resources directly in each Folder.
+// Folders whose Parent is not in folders are shown at the top Level.
+func printTreeFolders(folders []api.Folder, resources []api.Resource) error {
+	counts := map[string]int{}
+	for _, r := range resources {
+		counts[r.FolderParentID]++
+	}
+
+	known := map[string]bool{}
+	for _, f := range folders {
+		known[f.ID] = true
+	}
+	children := map[string][]api.Folder{}
+	for _, f := range folders {
+		parent := f.FolderParentID
+		if !known[parent] {
+			parent = ""
+		}
+		children[parent] = append(children[parent], f)
+	}
+
+	var build func(parent string, depth int) []pterm.TreeNode
+	build = func(parent string, depth int) []pterm.TreeNode {
+		list := children[parent]
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+		nodes := []pterm.TreeNode{}
+		// the depth check protects against cycles in inconsistent data
+		if depth > len(folders) {
+			return nodes
+		}
+		for _, f := range list {
+			nodes = append(nodes, pterm.TreeNode{
+				Text:     fmt.Sprintf("%v (%d Resources)", shellescape.StripUnsafe(f.Name), counts[f.ID]),
+				Children: build(f.ID, depth+1),
+			})
+		}
+		return nodes
+	}
+
+	root := pterm.TreeNode{
+		Text:     fmt.Sprintf("%v (%d Resources)", PathSeparator, counts[""]),
+		Children: build("", 0),
+	}
+	return pterm.DefaultTree.WithRoot(root).Render()
+}
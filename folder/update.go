@@ -10,22 +10,24 @@ import (
 
 // FolderUpdateCmd Updates a Passbolt Folder
 var FolderUpdateCmd = &cobra.Command{
-	Use:   "folder",
-	Short: "Updates a Passbolt Folder",
-	Long:  `Updates a Passbolt Folder`,
-	RunE:  FolderUpdate,
+	Use:               "folder [id]",
+	Short:             "Updates a Passbolt Folder",
+	Long:              `Updates a Passbolt Folder`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFolderArg,
+	RunE:              FolderUpdate,
 }
 
 func init() {
-	FolderUpdateCmd.Flags().String("id", "", "id of Folder to Update")
+	FolderUpdateCmd.Flags().String("id", "", "id, name or path of Folder to Update")
 	FolderUpdateCmd.Flags().StringP("name", "n", "", "Folder Name")
 
-	FolderUpdateCmd.MarkFlagRequired("id")
+	FolderUpdateCmd.RegisterFlagCompletionFunc("id", CompleteFolderIDs)
 	FolderUpdateCmd.MarkFlagRequired("name")
 }
 
 func FolderUpdate(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -44,6 +46,11 @@ func FolderUpdate(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	id, err = ResolveFolderID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
 	err = helper.UpdateFolder(
 		ctx,
 		client,
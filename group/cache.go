@@ -0,0 +1,20 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// CacheKind is the Name of the Group Metadata Cache
+const CacheKind = "groups"
+
+// FetchGroups fetches all Groups for the Metadata Cache.
+func FetchGroups(ctx context.Context, client *api.Client, _ []api.Group) ([]api.Group, error) {
+	groups, err := client.GetGroups(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Groups: %w", err)
+	}
+	return groups, nil
+}
@@ -0,0 +1,30 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// CompleteGroupIDs completes Group IDs with their Names as Description
+var CompleteGroupIDs = cache.CompleteIDs("group", fetchGroupCompletions)
+
+var completeGroupArg = cache.CompleteArgIDs("group", fetchGroupCompletions)
+
+func fetchGroupCompletions(ctx context.Context, client *api.Client) ([]cache.Completion, error) {
+	groups, err := client.GetGroups(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Groups: %w", err)
+	}
+
+	completions := make([]cache.Completion, len(groups))
+	for i, group := range groups {
+		completions[i] = cache.Completion{
+			ID:   group.ID,
+			Name: group.Name,
+		}
+	}
+	return completions, nil
+}
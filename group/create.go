@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/passbolt/go-passbolt-cli/user"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/helper"
 	"github.com/spf13/cobra"
@@ -20,8 +21,10 @@ var GroupCreateCmd = &cobra.Command{
 func init() {
 	GroupCreateCmd.Flags().StringP("name", "n", "", "Group Name")
 
-	GroupCreateCmd.Flags().StringArrayP("user", "u", []string{}, "Users to Add to Group")
-	GroupCreateCmd.Flags().StringArrayP("manager", "m", []string{}, "Managers to Add to Group (atleast 1 is required)")
+	GroupCreateCmd.Flags().StringArrayP("user", "u", []string{}, "Users to Add to Group, as id, username or full name")
+	GroupCreateCmd.Flags().StringArrayP("manager", "m", []string{}, "Managers to Add to Group (atleast 1 is required), as id, username or full name")
+	GroupCreateCmd.RegisterFlagCompletionFunc("user", user.CompleteUserIDs)
+	GroupCreateCmd.RegisterFlagCompletionFunc("manager", user.CompleteUserIDs)
 
 	GroupCreateCmd.MarkFlagRequired("name")
 	GroupCreateCmd.MarkFlagRequired("manager")
@@ -45,6 +48,26 @@ func GroupCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	users, err = util.ResolveUserIDs(ctx, client, users)
+	if err != nil {
+		return fmt.Errorf("Resolving Users: %w", err)
+	}
+
+	managers, err = util.ResolveUserIDs(ctx, client, managers)
+	if err != nil {
+		return fmt.Errorf("Resolving Managers: %w", err)
+	}
+
 	ops := []helper.GroupMembershipOperation{}
 	for _, user := range users {
 		ops = append(ops, helper.GroupMembershipOperation{
@@ -59,16 +82,6 @@ func GroupCreate(cmd *cobra.Command, args []string) error {
 		})
 	}
 
-	ctx, cancel := util.GetContext()
-	defer cancel()
-
-	client, err := util.GetClient(ctx)
-	if err != nil {
-		return err
-	}
-	defer util.SaveSessionKeysAndLogout(ctx, client)
-	cmd.SilenceUsage = true
-
 	id, err := helper.CreateGroup(
 		ctx,
 		client,
@@ -9,22 +9,25 @@ import (
 
 // GroupDeleteCmd Deletes a Group
 var GroupDeleteCmd = &cobra.Command{
-	Use:   "group",
-	Short: "Deletes a Passbolt Group",
-	Long:  `Deletes a Passbolt Group`,
-	RunE:  GroupDelete,
+	Use:               "group [id]",
+	Short:             "Deletes a Passbolt Group",
+	Long:              `Deletes a Passbolt Group`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeGroupArg,
+	RunE:              GroupDelete,
+}
+
+func init() {
+	GroupDeleteCmd.Flags().String("id", "", "id or name of Group to Delete")
+	GroupDeleteCmd.RegisterFlagCompletionFunc("id", CompleteGroupIDs)
 }
 
 func GroupDelete(cmd *cobra.Command, args []string) error {
-	resourceID, err := cmd.Flags().GetString("id")
+	resourceID, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
 
-	if resourceID == "" {
-		return fmt.Errorf("No ID to Delete Provided")
-	}
-
 	ctx, cancel := util.GetContext()
 	defer cancel()
 
@@ -35,6 +38,11 @@ func GroupDelete(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	resourceID, err = util.ResolveGroupID(ctx, client, resourceID)
+	if err != nil {
+		return fmt.Errorf("Resolving Group: %w", err)
+	}
+
 	err = client.DeleteGroup(ctx, resourceID)
 	if err != nil {
 		return fmt.Errorf("Deleting Group: %w", err)
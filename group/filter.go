@@ -9,7 +9,7 @@ import (
 	"github.com/passbolt/go-passbolt/api"
 )
 
-// Environments for CEl
+// Environments for CEL
 var celEnvOptions = []cel.EnvOption{
 	cel.Variable("ID", cel.StringType),
 	cel.Variable("Name", cel.StringType),
@@ -17,6 +17,16 @@ var celEnvOptions = []cel.EnvOption{
 	cel.Variable("ModifiedTimestamp", cel.TimestampType),
 }
 
+// groupCelVars returns the CEL activation for a group
+func groupCelVars(group api.Group) map[string]any {
+	return map[string]any{
+		"ID":                group.ID,
+		"Name":              group.Name,
+		"CreatedTimestamp":  group.Created.Time,
+		"ModifiedTimestamp": group.Modified.Time,
+	}
+}
+
 // Filters the slice groups by invoke CEL program for each group
 func filterGroups(groups *[]api.Group, celCmd string, ctx context.Context) ([]api.Group, error) {
 	if celCmd == "" {
@@ -30,12 +40,7 @@ func filterGroups(groups *[]api.Group, celCmd string, ctx context.Context) ([]ap
 
 	filteredGroups := []api.Group{}
 	for _, group := range *groups {
-		val, _, err := (*program).ContextEval(ctx, map[string]any{
-			"ID":                group.ID,
-			"Name":              group.Name,
-			"CreatedTimestamp":  group.Created.Time,
-			"ModifiedTimestamp": group.Modified.Time,
-		})
+		val, _, err := (*program).ContextEval(ctx, groupCelVars(group))
 
 		if err != nil {
 			return nil, err
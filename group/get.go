@@ -14,22 +14,24 @@ import (
 
 // GroupGetCmd Gets a Passbolt Group
 var GroupGetCmd = &cobra.Command{
-	Use:   "group",
-	Short: "Gets a Passbolt Group",
-	Long:  `Gets a Passbolt Group`,
-	RunE:  GroupGet,
+	Use:               "group [id]",
+	Short:             "Gets a Passbolt Group",
+	Long:              `Gets a Passbolt Group`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeGroupArg,
+	RunE:              GroupGet,
 }
 
 func init() {
-	GroupGetCmd.Flags().String("id", "", "id of Group to Get")
+	GroupGetCmd.Flags().String("id", "", "id or name of Group to Get")
 
 	GroupGetCmd.Flags().StringArrayP("column", "c", []string{"UserID", "Username", "UserFirstName", "UserLastName", "IsGroupManager"}, "Membership Columns to return, possible Columns:\nUserID, Username, UserFirstName, UserLastName, IsGroupManager")
 
-	GroupGetCmd.MarkFlagRequired("id")
+	GroupGetCmd.RegisterFlagCompletionFunc("id", CompleteGroupIDs)
 }
 
 func GroupGet(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -52,6 +54,11 @@ func GroupGet(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	id, err = util.ResolveGroupID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Group: %w", err)
+	}
+
 	name, memberships, err := helper.GetGroup(
 		ctx,
 		client,
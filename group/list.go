@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt-cli/user"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/api"
 	"github.com/spf13/cobra"
@@ -27,8 +29,10 @@ var GroupListCmd = &cobra.Command{
 
 func init() {
 	flags := GroupListCmd.Flags()
-	flags.StringArrayP("user", "u", []string{}, "Groups that are shared with group")
-	flags.StringArrayP("manager", "m", []string{}, "Groups that are in folder")
+	flags.StringArrayP("user", "u", []string{}, "Groups that have this User as Member, as id, username or full name")
+	flags.StringArrayP("manager", "m", []string{}, "Groups that have this User as Manager, as id, username or full name")
+	GroupListCmd.RegisterFlagCompletionFunc("user", user.CompleteUserIDs)
+	GroupListCmd.RegisterFlagCompletionFunc("manager", user.CompleteUserIDs)
 	flags.StringArrayP("column", "c", defaultTableColumns, "Columns to return (default list only for table format; JSON format includes all fields by default).\nPossible Columns: ID, Name, CreatedTimestamp, ModifiedTimestamp")
 }
 
@@ -39,6 +43,9 @@ type groupListConfig struct {
 	columnsChanged bool
 	jsonOutput     bool
 	celFilter      string
+	sortKeys       []util.SortKey
+	limit          int
+	offset         int
 }
 
 func GroupList(cmd *cobra.Command, args []string) error {
@@ -50,25 +57,57 @@ func GroupList(cmd *cobra.Command, args []string) error {
 	ctx, cancel := util.GetContext()
 	defer cancel()
 
-	client, err := util.GetClient(ctx)
-	if err != nil {
-		return err
+	var groups []api.Group
+	// Server side Filters can't be applied to the Cache
+	if cache.Enabled() && len(config.users) == 0 && len(config.managers) == 0 {
+		client, err := cache.NewClient()
+		if err != nil {
+			return err
+		}
+		defer client.Logout(ctx)
+		cmd.SilenceUsage = true
+
+		groups, err = cache.List(ctx, client, CacheKind, FetchGroups)
+		if err != nil {
+			return err
+		}
+	} else {
+		client, err := util.GetClient(ctx)
+		if err != nil {
+			return err
+		}
+		defer util.SaveSessionKeysAndLogout(ctx, client)
+		cmd.SilenceUsage = true
+
+		config.users, err = util.ResolveUserIDs(ctx, client, config.users)
+		if err != nil {
+			return fmt.Errorf("Resolving Users: %w", err)
+		}
+
+		config.managers, err = util.ResolveUserIDs(ctx, client, config.managers)
+		if err != nil {
+			return fmt.Errorf("Resolving Managers: %w", err)
+		}
+
+		groups, err = client.GetGroups(ctx, &api.GetGroupsOptions{
+			FilterHasUsers:    config.users,
+			FilterHasManagers: config.managers,
+		})
+		if err != nil {
+			return fmt.Errorf("Listing Group: %w", err)
+		}
 	}
-	defer util.SaveSessionKeysAndLogout(ctx, client)
-	cmd.SilenceUsage = true
 
-	groups, err := client.GetGroups(ctx, &api.GetGroupsOptions{
-		FilterHasUsers:    config.users,
-		FilterHasManagers: config.managers,
-	})
+	groups, err = filterGroups(&groups, config.celFilter, ctx)
 	if err != nil {
-		return fmt.Errorf("Listing Group: %w", err)
+		return err
 	}
 
-	groups, err = filterGroups(&groups, config.celFilter, ctx)
+	err = util.SortByKeys(ctx, groups, config.sortKeys, groupCelVars, celEnvOptions...)
 	if err != nil {
 		return err
 	}
+	groups = util.Paginate(groups, config.offset, config.limit)
 
 	if config.jsonOutput {
 		return printJsonGroups(groups, config.columnsChanged, config.columns)
@@ -172,6 +211,10 @@ func parseGroupListFlags(cmd *cobra.Command) (*groupListConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	sortKeys, limit, offset, err := util.GetSortAndPagination(cmd, celEnvOptions...)
+	if err != nil {
+		return nil, err
+	}
 
 	return &groupListConfig{
 		users:          users,
@@ -180,5 +223,8 @@ func parseGroupListFlags(cmd *cobra.Command) (*groupListConfig, error) {
 		columnsChanged: cmd.Flags().Changed("column"),
 		jsonOutput:     jsonOutput,
 		celFilter:      celFilter,
+		sortKeys:       sortKeys,
+		limit:          limit,
+		offset:         offset,
 	}, nil
 }
@@ -3,6 +3,7 @@ package group
 import (
 	"fmt"
 
+	"github.com/passbolt/go-passbolt-cli/user"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/helper"
 	"github.com/spf13/cobra"
@@ -10,26 +11,30 @@ import (
 
 // GroupUpdateCmd Updates a Passbolt Group
 var GroupUpdateCmd = &cobra.Command{
-	Use:   "group",
-	Short: "Updates a Passbolt Group",
-	Long:  `Updates a Passbolt Group`,
-	RunE:  GroupUpdate,
+	Use:               "group [id]",
+	Short:             "Updates a Passbolt Group",
+	Long:              `Updates a Passbolt Group`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeGroupArg,
+	RunE:              GroupUpdate,
 }
 
 func init() {
-	GroupUpdateCmd.Flags().String("id", "", "id of Group to Update")
+	GroupUpdateCmd.Flags().String("id", "", "id or name of Group to Update")
 	GroupUpdateCmd.Flags().StringP("name", "n", "", "Group Name")
 
 	GroupUpdateCmd.Flags().BoolP("delete", "d", false, "Remove Users/Managers from Group (default is Adding Users/Managers)")
 
-	GroupUpdateCmd.Flags().StringArrayP("user", "u", []string{}, "Users to Add/Remove to/from Group(Including Group Managers)")
-	GroupUpdateCmd.Flags().StringArrayP("manager", "m", []string{}, "Managers to Add/Remove to/from Group")
+	GroupUpdateCmd.Flags().StringArrayP("user", "u", []string{}, "Users to Add/Remove to/from Group(Including Group Managers), as id, username or full name")
+	GroupUpdateCmd.Flags().StringArrayP("manager", "m", []string{}, "Managers to Add/Remove to/from Group, as id, username or full name")
+	GroupUpdateCmd.RegisterFlagCompletionFunc("user", user.CompleteUserIDs)
+	GroupUpdateCmd.RegisterFlagCompletionFunc("manager", user.CompleteUserIDs)
 
-	GroupUpdateCmd.MarkFlagRequired("id")
+	GroupUpdateCmd.RegisterFlagCompletionFunc("id", CompleteGroupIDs)
 }
 
 func GroupUpdate(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -50,6 +55,31 @@ func GroupUpdate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	id, err = util.ResolveGroupID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Group: %w", err)
+	}
+
+	users, err = util.ResolveUserIDs(ctx, client, users)
+	if err != nil {
+		return fmt.Errorf("Resolving Users: %w", err)
+	}
+
+	managers, err = util.ResolveUserIDs(ctx, client, managers)
+	if err != nil {
+		return fmt.Errorf("Resolving Managers: %w", err)
+	}
+
 	ops := []helper.GroupMembershipOperation{}
 	for _, user := range users {
 		ops = append(ops, helper.GroupMembershipOperation{
@@ -66,16 +96,6 @@ func GroupUpdate(cmd *cobra.Command, args []string) error {
 		})
 	}
 
-	ctx, cancel := util.GetContext()
-	defer cancel()
-
-	client, err := util.GetClient(ctx)
-	if err != nil {
-		return err
-	}
-	defer util.SaveSessionKeysAndLogout(ctx, client)
-	cmd.SilenceUsage = true
-
 	err = helper.UpdateGroup(
 		ctx,
 		client,
@@ -0,0 +1,76 @@
+package manifest
+
+import (
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// ApplyCmd Applies a Manifest
+var ApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Changes the Vault to match a Manifest",
+	Long: `Changes the Vault to match a YAML Manifest of Folders, Groups, Resources and Shares.
+The Changes are shown like with "plan" and need to be confirmed first.
+Only Creates, Updates, Moves and new or changed Shares are executed, nothing is ever deleted.`,
+	Args: cobra.NoArgs,
+	RunE: Apply,
+}
+
+func init() {
+	ApplyCmd.Flags().StringP("file", "f", "", "Manifest File")
+	ApplyCmd.Flags().BoolP("yes", "y", false, "Don't ask for Confirmation before applying the Changes")
+	ApplyCmd.MarkFlagRequired("file")
+}
+
+func Apply(cmd *cobra.Command, args []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+
+	m, err := Load(file)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	changes, s, err := buildPlan(ctx, client, m)
+	if err != nil {
+		return err
+	}
+	printPlan(changes)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	err = util.Confirm(fmt.Sprintf("Apply %d Changes?", len(changes)), yes)
+	if err != nil {
+		return err
+	}
+
+	// Changes depend on each other, so they are applied in order and stop at the first Error
+	for i, c := range changes {
+		err = c.apply(ctx, s)
+		if err != nil {
+			return fmt.Errorf("Applying %v: %w (%d of %d Changes applied)", c, err, i, len(changes))
+		}
+		fmt.Printf("Applied %v\n", c)
+	}
+	fmt.Printf("Applied %d Changes\n", len(changes))
+	return nil
+}
@@ -0,0 +1,178 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"go.yaml.in/yaml/v3"
+)
+
+// Manifest describes the desired State of a Vault.
+// Applying it only creates and changes Entities, anything not in the Manifest is left untouched.
+type Manifest struct {
+	Folders   []Folder   `yaml:"folders"`
+	Groups    []Group    `yaml:"groups"`
+	Resources []Resource `yaml:"resources"`
+}
+
+// Folder is a Folder referenced by its Path like "Infra/AWS", missing Parents are created as well
+type Folder struct {
+	Path   string  `yaml:"path"`
+	Shares []Share `yaml:"shares"`
+}
+
+// Group is a Group with Users that should at least be Members or Managers
+type Group struct {
+	Name     string   `yaml:"name"`
+	Members  []string `yaml:"members"`
+	Managers []string `yaml:"managers"`
+}
+
+// Resource is a Resource referenced by its Path like "Infra/AWS/root".
+// If ID is set, the existing Resource is moved and renamed to Path instead.
+// Fields which are not set are not managed and can't be empty, the Password can also be read from an Environment Variable or File.
+type Resource struct {
+	ID           string  `yaml:"id"`
+	Path         string  `yaml:"path"`
+	Username     *string `yaml:"username"`
+	URI          *string `yaml:"uri"`
+	Description  *string `yaml:"description"`
+	Password     *string `yaml:"password"`
+	PasswordEnv  string  `yaml:"passwordEnv"`
+	PasswordFile string  `yaml:"passwordFile"`
+	Shares       []Share `yaml:"shares"`
+}
+
+// Share grants a User or Group a Permission Type (read, update or owner)
+type Share struct {
+	User  string `yaml:"user"`
+	Group string `yaml:"group"`
+	Type  string `yaml:"type"`
+}
+
+// Load reads and validates a Manifest File
+func Load(file string) (*Manifest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Reading Manifest: %w", err)
+	}
+
+	var m Manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&m)
+	if err != nil {
+		return nil, fmt.Errorf("Parsing Manifest: %w", err)
+	}
+
+	err = m.validate()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid Manifest: %w", err)
+	}
+	return &m, nil
+}
+
+func (m *Manifest) validate() error {
+	for i, f := range m.Folders {
+		m.Folders[i].Path = cleanPath(f.Path)
+		if m.Folders[i].Path == "" {
+			return fmt.Errorf("Folder %d has no path", i+1)
+		}
+		err := validateShares(f.Shares)
+		if err != nil {
+			return fmt.Errorf("Folder %q: %w", f.Path, err)
+		}
+	}
+
+	for i, g := range m.Groups {
+		if g.Name == "" {
+			return fmt.Errorf("Group %d has no name", i+1)
+		}
+	}
+
+	for i, r := range m.Resources {
+		m.Resources[i].Path = cleanPath(r.Path)
+		if m.Resources[i].Path == "" {
+			return fmt.Errorf("Resource %d has no path", i+1)
+		}
+
+		// Empty Values mean unchanged when updating a Resource, so they could never be applied
+		fields := []string{"username", "uri", "description", "password"}
+		for j, value := range []*string{r.Username, r.URI, r.Description, r.Password} {
+			if value != nil && *value == "" {
+				return fmt.Errorf("Resource %q: %v can't be empty, remove it to leave it unmanaged", r.Path, fields[j])
+			}
+		}
+
+		sources := 0
+		if r.Password != nil {
+			sources++
+		}
+		if r.PasswordEnv != "" {
+			sources++
+		}
+		if r.PasswordFile != "" {
+			sources++
+		}
+		if sources > 1 {
+			return fmt.Errorf("Resource %q: only one of password, passwordEnv and passwordFile can be set", r.Path)
+		}
+
+		err := validateShares(r.Shares)
+		if err != nil {
+			return fmt.Errorf("Resource %q: %w", r.Path, err)
+		}
+	}
+	return nil
+}
+
+func validateShares(shares []Share) error {
+	for _, s := range shares {
+		if (s.User == "") == (s.Group == "") {
+			return fmt.Errorf("a share needs either a user or a group")
+		}
+		_, err := util.ParsePermissionType(s.Type)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// password returns the desired Password and if it is managed by the Manifest at all
+func (r Resource) password() (string, bool, error) {
+	switch {
+	case r.Password != nil:
+		return *r.Password, true, nil
+	case r.PasswordEnv != "":
+		password, ok := os.LookupEnv(r.PasswordEnv)
+		if !ok {
+			return "", false, fmt.Errorf("Environment Variable %v for Resource %q is not set", r.PasswordEnv, r.Path)
+		}
+		return password, true, nil
+	case r.PasswordFile != "":
+		data, err := os.ReadFile(r.PasswordFile)
+		if err != nil {
+			return "", false, fmt.Errorf("Reading Password File for Resource %q: %w", r.Path, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), true, nil
+	}
+	return "", false, nil
+}
+
+func cleanPath(path string) string {
+	return strings.Trim(strings.TrimSpace(path), folder.PathSeparator)
+}
+
+// splitPath returns the Path of the Parent Folder and the Name
+func splitPath(path string) (string, string) {
+	i := strings.LastIndex(path, folder.PathSeparator)
+	if i == -1 {
+		return "", path
+	}
+	return path[:i], path[i+1:]
+}
@@ -0,0 +1,613 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/resource"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+	"github.com/spf13/cobra"
+)
+
+// PlanCmd Shows the Changes needed to apply a Manifest
+var PlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Shows the Changes needed to make the Vault match a Manifest",
+	Long: `Shows the Changes needed to make the Vault match a YAML Manifest of Folders, Groups, Resources and Shares.
+Nothing is changed, use "apply" to execute the Changes.`,
+	Args: cobra.NoArgs,
+	RunE: Plan,
+}
+
+func init() {
+	PlanCmd.Flags().StringP("file", "f", "", "Manifest File")
+	PlanCmd.MarkFlagRequired("file")
+}
+
+func Plan(cmd *cobra.Command, args []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+
+	m, err := Load(file)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	changes, _, err := buildPlan(ctx, client, m)
+	if err != nil {
+		return err
+	}
+	printPlan(changes)
+	return nil
+}
+
+// change is a single Step of a Plan
+type change struct {
+	// symbol is "+" for creates, "~" for changes and ">" for moves
+	symbol  string
+	kind    string
+	name    string
+	details []string
+	apply   func(ctx context.Context, s *state) error
+}
+
+func (c change) String() string {
+	return fmt.Sprintf("%v %v %q", c.symbol, c.kind, c.name)
+}
+
+// state holds the IDs of all Entities referenced by the Manifest, Changes add the IDs of Entities they create
+type state struct {
+	client      *api.Client
+	folderIDs   map[string]string
+	groupIDs    map[string]string
+	resourceIDs map[string]string
+}
+
+type planner struct {
+	ctx     context.Context
+	state   *state
+	changes []change
+
+	plannedFolders         map[string]bool
+	ambiguousFolderPaths   map[string]bool
+	ambiguousResourcePaths map[string]bool
+	groups                 map[string]api.Group
+	plannedGroups          map[string]bool
+	resources              map[string]resource.DecryptedResource
+	resourcePaths          map[string]string
+}
+
+// buildPlan compares the Manifest with the Server and returns all Changes in the Order they need to be applied
+func buildPlan(ctx context.Context, client *api.Client, m *Manifest) ([]change, *state, error) {
+	p := &planner{
+		ctx: ctx,
+		state: &state{
+			client:      client,
+			folderIDs:   map[string]string{},
+			groupIDs:    map[string]string{},
+			resourceIDs: map[string]string{},
+		},
+		plannedFolders:         map[string]bool{},
+		ambiguousFolderPaths:   map[string]bool{},
+		ambiguousResourcePaths: map[string]bool{},
+		groups:                 map[string]api.Group{},
+		plannedGroups:          map[string]bool{},
+		resources:              map[string]resource.DecryptedResource{},
+		resourcePaths:          map[string]string{},
+	}
+
+	err := p.loadServerState()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, f := range m.Folders {
+		err = p.ensureFolder(f.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, g := range m.Groups {
+		err = p.planGroup(g)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, r := range m.Resources {
+		err = p.planResource(r)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Shares come last so that all Folders, Groups and Resources exist
+	for _, f := range m.Folders {
+		err = p.planFolderShares(f)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, r := range m.Resources {
+		err = p.planResourceShares(r)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return p.changes, p.state, nil
+}
+
+func (p *planner) loadServerState() error {
+	client := p.state.client
+
+	folders, err := client.GetFolders(p.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Listing Folders: %w", err)
+	}
+	folderPaths := folder.GetFolderPaths(folders)
+	for id, path := range folderPaths {
+		if _, ok := p.state.folderIDs[path]; ok {
+			p.ambiguousFolderPaths[path] = true
+		}
+		p.state.folderIDs[path] = id
+	}
+
+	groups, err := client.GetGroups(p.ctx, &api.GetGroupsOptions{
+		ContainGroupsUsers: true,
+	})
+	if err != nil {
+		return fmt.Errorf("Listing Groups: %w", err)
+	}
+	for _, g := range groups {
+		p.groups[g.Name] = g
+		p.state.groupIDs[g.Name] = g.ID
+	}
+
+	resources, err := client.GetResources(p.ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Listing Resources: %w", err)
+	}
+	decrypted, err := resource.DecryptResourcesParallel(p.ctx, client, resources, false)
+	if err != nil {
+		return err
+	}
+	for _, d := range decrypted {
+		path := d.Name
+		if parent, ok := folderPaths[d.Resource.FolderParentID]; ok {
+			path = parent + folder.PathSeparator + d.Name
+		}
+		if _, ok := p.state.resourceIDs[path]; ok {
+			p.ambiguousResourcePaths[path] = true
+		}
+		p.state.resourceIDs[path] = d.Resource.ID
+		p.resources[d.Resource.ID] = d
+		p.resourcePaths[d.Resource.ID] = path
+	}
+	return nil
+}
+
+// ensureFolder plans the creation of a Folder and all of its missing Parents
+func (p *planner) ensureFolder(path string) error {
+	if path == "" || p.plannedFolders[path] {
+		return nil
+	}
+	if p.ambiguousFolderPaths[path] {
+		return fmt.Errorf("Folder Path %q is ambiguous", path)
+	}
+	if _, ok := p.state.folderIDs[path]; ok {
+		return nil
+	}
+
+	parent, name := splitPath(path)
+	err := p.ensureFolder(parent)
+	if err != nil {
+		return err
+	}
+
+	p.plannedFolders[path] = true
+	p.changes = append(p.changes, change{
+		symbol: "+",
+		kind:   "folder",
+		name:   path,
+		apply: func(ctx context.Context, s *state) error {
+			id, err := helper.CreateFolder(ctx, s.client, s.folderIDs[parent], name)
+			if err != nil {
+				return err
+			}
+			s.folderIDs[path] = id
+			return nil
+		},
+	})
+	return nil
+}
+
+func (p *planner) planGroup(g Group) error {
+	members, err := util.ResolveUserIDs(p.ctx, p.state.client, g.Members)
+	if err != nil {
+		return fmt.Errorf("Group %q: Resolving Members: %w", g.Name, err)
+	}
+	managers, err := util.ResolveUserIDs(p.ctx, p.state.client, g.Managers)
+	if err != nil {
+		return fmt.Errorf("Group %q: Resolving Managers: %w", g.Name, err)
+	}
+
+	existing, exists := p.groups[g.Name]
+	current := map[string]bool{}
+	for _, membership := range existing.GroupUsers {
+		current[membership.UserID] = membership.IsAdmin
+	}
+
+	ops := []helper.GroupMembershipOperation{}
+	details := []string{}
+	for i, id := range managers {
+		isManager, isMember := current[id]
+		if isManager {
+			continue
+		}
+		ops = append(ops, helper.GroupMembershipOperation{UserID: id, IsGroupManager: true})
+		if isMember {
+			details = append(details, fmt.Sprintf("~ %v: member -> manager", g.Managers[i]))
+		} else {
+			details = append(details, fmt.Sprintf("+ manager %v", g.Managers[i]))
+		}
+	}
+	for i, id := range members {
+		// Managers are Members as well, existing Managers are never demoted
+		if _, ok := current[id]; ok || slices.Contains(managers, id) {
+			continue
+		}
+		ops = append(ops, helper.GroupMembershipOperation{UserID: id})
+		details = append(details, fmt.Sprintf("+ member %v", g.Members[i]))
+	}
+
+	if !exists {
+		// Passbolt can't create a Group without Manager, failing here keeps apply from stopping halfway
+		if len(managers) == 0 {
+			return fmt.Errorf("Group %q: a new group needs at least one manager", g.Name)
+		}
+		p.plannedGroups[g.Name] = true
+		p.changes = append(p.changes, change{
+			symbol:  "+",
+			kind:    "group",
+			name:    g.Name,
+			details: details,
+			apply: func(ctx context.Context, s *state) error {
+				id, err := helper.CreateGroup(ctx, s.client, g.Name, ops)
+				if err != nil {
+					return err
+				}
+				s.groupIDs[g.Name] = id
+				return nil
+			},
+		})
+		return nil
+	}
+
+	if len(ops) == 0 {
+		return nil
+	}
+	p.changes = append(p.changes, change{
+		symbol:  "~",
+		kind:    "group",
+		name:    g.Name,
+		details: details,
+		apply: func(ctx context.Context, s *state) error {
+			return helper.UpdateGroup(ctx, s.client, existing.ID, "", ops)
+		},
+	})
+	return nil
+}
+
+func (p *planner) planResource(r Resource) error {
+	folderPath, name := splitPath(r.Path)
+	err := p.ensureFolder(folderPath)
+	if err != nil {
+		return err
+	}
+
+	password, passwordManaged, err := r.password()
+	if err != nil {
+		return err
+	}
+
+	id := r.ID
+	if id == "" {
+		if p.ambiguousResourcePaths[r.Path] {
+			return fmt.Errorf("Resource Path %q is ambiguous, set the id of the Resource", r.Path)
+		}
+		id = p.state.resourceIDs[r.Path]
+	}
+
+	if id == "" {
+		details := []string{}
+		if r.Username != nil {
+			details = append(details, fmt.Sprintf("username: %q", *r.Username))
+		}
+		if r.URI != nil {
+			details = append(details, fmt.Sprintf("uri: %q", *r.URI))
+		}
+		if r.Description != nil {
+			details = append(details, "description: (sensitive)")
+		}
+		if passwordManaged {
+			details = append(details, "password: (sensitive)")
+		}
+
+		p.changes = append(p.changes, change{
+			symbol:  "+",
+			kind:    "resource",
+			name:    r.Path,
+			details: details,
+			apply: func(ctx context.Context, s *state) error {
+				id, err := helper.CreateResource(ctx, s.client, s.folderIDs[folderPath], name, deref(r.Username), deref(r.URI), password, deref(r.Description))
+				if err != nil {
+					return err
+				}
+				s.resourceIDs[r.Path] = id
+				return nil
+			},
+		})
+		return nil
+	}
+
+	existing, ok := p.resources[id]
+	if !ok {
+		return fmt.Errorf("Resource %q: no Resource with id %v found", r.Path, id)
+	}
+	p.state.resourceIDs[r.Path] = id
+
+	// Folders which don't exist yet always mean a move
+	if existing.Resource.FolderParentID != p.state.folderIDs[folderPath] || p.plannedFolders[folderPath] {
+		p.changes = append(p.changes, change{
+			symbol:  ">",
+			kind:    "resource",
+			name:    r.Path,
+			details: []string{fmt.Sprintf("from %q", p.resourcePaths[id])},
+			apply: func(ctx context.Context, s *state) error {
+				return helper.MoveResource(ctx, s.client, id, s.folderIDs[folderPath])
+			},
+		})
+	}
+
+	// Empty Values mean unchanged for helper.UpdateResource
+	var newName, newUsername, newURI, newPassword, newDescription string
+	details := []string{}
+	if name != existing.Name {
+		newName = name
+		details = append(details, fmt.Sprintf("name: %q -> %q", existing.Name, name))
+	}
+	if r.Username != nil && *r.Username != existing.Username {
+		newUsername = *r.Username
+		details = append(details, fmt.Sprintf("username: %q -> %q", existing.Username, *r.Username))
+	}
+	if r.URI != nil && *r.URI != existing.URI {
+		newURI = *r.URI
+		details = append(details, fmt.Sprintf("uri: %q -> %q", existing.URI, *r.URI))
+	}
+	if passwordManaged || r.Description != nil {
+		_, _, _, _, currentPassword, currentDescription, err := helper.GetResource(p.ctx, p.state.client, id)
+		if err != nil {
+			return fmt.Errorf("Getting Resource %q: %w", r.Path, err)
+		}
+		if passwordManaged && password != currentPassword {
+			newPassword = password
+			details = append(details, "password: (sensitive)")
+		}
+		if r.Description != nil && *r.Description != currentDescription {
+			newDescription = *r.Description
+			details = append(details, "description: (sensitive)")
+		}
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+	p.changes = append(p.changes, change{
+		symbol:  "~",
+		kind:    "resource",
+		name:    r.Path,
+		details: details,
+		apply: func(ctx context.Context, s *state) error {
+			return helper.UpdateResource(ctx, s.client, id, newName, newUsername, newURI, newPassword, newDescription)
+		},
+	})
+	return nil
+}
+
+func (p *planner) planFolderShares(f Folder) error {
+	if len(f.Shares) == 0 {
+		return nil
+	}
+
+	var current []api.Permission
+	if id, ok := p.state.folderIDs[f.Path]; ok && !p.plannedFolders[f.Path] {
+		existing, err := p.state.client.GetFolder(p.ctx, id, &api.GetFolderOptions{
+			ContainPermissions: true,
+		})
+		if err != nil {
+			return fmt.Errorf("Getting Folder %q: %w", f.Path, err)
+		}
+		current = existing.Permissions
+	}
+
+	return p.planShares("folder", f.Path, f.Shares, current, func(ctx context.Context, s *state, ops []helper.ShareOperation) error {
+		id := s.folderIDs[f.Path]
+		folder, err := s.client.GetFolder(ctx, id, &api.GetFolderOptions{
+			ContainPermissions: true,
+		})
+		if err != nil {
+			return err
+		}
+		ops = pendingShareOperations(folder.Permissions, ops)
+		if len(ops) == 0 {
+			return nil
+		}
+		return helper.ShareFolder(ctx, s.client, id, ops)
+	})
+}
+
+func (p *planner) planResourceShares(r Resource) error {
+	if len(r.Shares) == 0 {
+		return nil
+	}
+
+	var current []api.Permission
+	if id, ok := p.state.resourceIDs[r.Path]; ok {
+		var err error
+		current, err = p.state.client.GetResourcePermissions(p.ctx, id)
+		if err != nil {
+			return fmt.Errorf("Getting Permissions of Resource %q: %w", r.Path, err)
+		}
+	}
+
+	return p.planShares("resource", r.Path, r.Shares, current, func(ctx context.Context, s *state, ops []helper.ShareOperation) error {
+		id := s.resourceIDs[r.Path]
+		permissions, err := s.client.GetResourcePermissions(ctx, id)
+		if err != nil {
+			return err
+		}
+		ops = pendingShareOperations(permissions, ops)
+		if len(ops) == 0 {
+			return nil
+		}
+		return helper.ShareResource(ctx, s.client, id, ops)
+	})
+}
+
+// shareTarget is a resolved Share, groupName is set for Groups which are only created while applying
+type shareTarget struct {
+	op        helper.ShareOperation
+	groupName string
+}
+
+func (p *planner) planShares(kind, path string, shares []Share, current []api.Permission, share func(ctx context.Context, s *state, ops []helper.ShareOperation) error) error {
+	targets := []shareTarget{}
+	details := []string{}
+	for _, sh := range shares {
+		pType, err := util.ParsePermissionType(sh.Type)
+		if err != nil {
+			return err
+		}
+
+		target := shareTarget{op: helper.ShareOperation{Type: pType}}
+		display := ""
+		if sh.User != "" {
+			target.op.ARO = "User"
+			target.op.AROID, err = util.ResolveUserID(p.ctx, p.state.client, sh.User)
+			if err != nil {
+				return fmt.Errorf("%v %q: %w", kind, path, err)
+			}
+			display = "user " + sh.User
+		} else {
+			target.op.ARO = "Group"
+			display = "group " + sh.Group
+			switch {
+			case util.IsUUID(sh.Group):
+				target.op.AROID = sh.Group
+			case p.plannedGroups[sh.Group]:
+				target.groupName = sh.Group
+			default:
+				group, ok := p.groups[sh.Group]
+				if !ok {
+					return fmt.Errorf("%v %q: No group found matching %q", kind, path, sh.Group)
+				}
+				target.op.AROID = group.ID
+			}
+		}
+
+		existing := findPermission(current, target.op.ARO, target.op.AROID)
+		switch {
+		case existing == nil:
+			details = append(details, fmt.Sprintf("+ %v: %v", display, util.PermissionTypeName(pType)))
+		case existing.Type != pType:
+			details = append(details, fmt.Sprintf("~ %v: %v -> %v", display, util.PermissionTypeName(existing.Type), util.PermissionTypeName(pType)))
+		default:
+			continue
+		}
+		targets = append(targets, target)
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+	p.changes = append(p.changes, change{
+		symbol:  "~",
+		kind:    "share " + kind,
+		name:    path,
+		details: details,
+		apply: func(ctx context.Context, s *state) error {
+			ops := make([]helper.ShareOperation, len(targets))
+			for i, t := range targets {
+				ops[i] = t.op
+				if t.groupName != "" {
+					ops[i].AROID = s.groupIDs[t.groupName]
+				}
+			}
+			return share(ctx, s, ops)
+		},
+	})
+	return nil
+}
+
+func findPermission(permissions []api.Permission, aro, aroID string) *api.Permission {
+	for i := range permissions {
+		if permissions[i].ARO == aro && permissions[i].AROForeignKey == aroID {
+			return &permissions[i]
+		}
+	}
+	return nil
+}
+
+// pendingShareOperations drops Operations which are already in place, e.g. the Owner Permission of the Creator of a new Resource
+func pendingShareOperations(permissions []api.Permission, ops []helper.ShareOperation) []helper.ShareOperation {
+	pending := []helper.ShareOperation{}
+	for _, op := range ops {
+		if existing := findPermission(permissions, op.ARO, op.AROID); existing != nil && existing.Type == op.Type {
+			continue
+		}
+		pending = append(pending, op)
+	}
+	return pending
+}
+
+// printPlan prints the Changes like a Terraform Plan
+func printPlan(changes []change) {
+	if len(changes) == 0 {
+		fmt.Println("No Changes, the Vault matches the Manifest.")
+		return
+	}
+
+	counts := map[string]int{}
+	for _, c := range changes {
+		fmt.Println(c)
+		for _, detail := range c.details {
+			fmt.Printf("    %v\n", detail)
+		}
+		counts[c.symbol]++
+	}
+	fmt.Printf("\nPlan: %d to create, %d to change, %d to move.\n", counts["+"], counts["~"], counts[">"])
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,306 @@
+package report
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/resource"
+	"github.com/passbolt/go-passbolt-cli/user"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// AccessCmd Reports who can Access which Resources and Folders
+var AccessCmd = &cobra.Command{
+	Use:   "access",
+	Short: "Reports who can Access which Resources and Folders",
+	Long: `Reports the Permissions of all Resources and Folders with Group Permissions expanded to their Members.
+Every Row is one Way a User can access an Entity, either directly or through a Group.
+Use --resource or --folder to answer who can access an Entity and --user to answer what a User can access.`,
+	Args: cobra.NoArgs,
+	RunE: Access,
+}
+
+func init() {
+	AccessCmd.Flags().String("resource", "", "Only report the Resource with this id, name or path")
+	AccessCmd.Flags().String("folder", "", "Only report the Folder with this id or path")
+	AccessCmd.Flags().String("user", "", "Only report what the User with this id, username or name can access")
+	AccessCmd.Flags().String("format", "table", "Output Format: table, csv or json")
+	AccessCmd.RegisterFlagCompletionFunc("resource", resource.CompleteResourceIDs)
+	AccessCmd.RegisterFlagCompletionFunc("folder", folder.CompleteFolderIDs)
+	AccessCmd.RegisterFlagCompletionFunc("user", user.CompleteUserIDs)
+}
+
+// AccessEntry is one Way a User can access a Resource or Folder
+type AccessEntry struct {
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	Path       string `json:"path"`
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	FullName   string `json:"full_name"`
+	Permission string `json:"permission"`
+	// Via is "direct" or the Name of the Group granting the Permission, its ID if the Group is unknown
+	Via string `json:"via"`
+}
+
+// accessTarget is a Resource or Folder and its Permissions
+type accessTarget struct {
+	entityType  string
+	id          string
+	path        string
+	permissions []api.Permission
+}
+
+func Access(cmd *cobra.Command, args []string) error {
+	resourceInput, err := cmd.Flags().GetString("resource")
+	if err != nil {
+		return err
+	}
+	folderInput, err := cmd.Flags().GetString("folder")
+	if err != nil {
+		return err
+	}
+	userInput, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "table", "csv", "json":
+	default:
+		return fmt.Errorf("Unknown Format %q, use table, csv or json", format)
+	}
+	if resourceInput != "" && folderInput != "" {
+		return fmt.Errorf("Either --resource or --folder can be given, not both")
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	userID, err := util.ResolveUserID(ctx, client, userInput)
+	if err != nil {
+		return fmt.Errorf("Resolving User: %w", err)
+	}
+	resourceID, err := resource.ResolveResourceID(ctx, client, resourceInput)
+	if err != nil {
+		return fmt.Errorf("Resolving Resource: %w", err)
+	}
+	folderID, err := folder.ResolveFolderID(ctx, client, folderInput)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
+	targets, err := getAccessTargets(ctx, client, resourceID, folderID)
+	if err != nil {
+		return err
+	}
+
+	users, err := client.GetUsers(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Listing Users: %w", err)
+	}
+	groups, err := client.GetGroups(ctx, &api.GetGroupsOptions{
+		ContainGroupsUsers: true,
+	})
+	if err != nil {
+		return fmt.Errorf("Listing Groups: %w", err)
+	}
+
+	entries := expandAccess(targets, users, groups)
+	if userID != "" {
+		filtered := []AccessEntry{}
+		for _, e := range entries {
+			if e.UserID == userID {
+				filtered = append(filtered, e)
+			}
+		}
+		entries = filtered
+	}
+
+	switch format {
+	case "json":
+		jsonEntries, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonEntries))
+		return nil
+	case "csv":
+		return printCSVAccess(entries)
+	}
+	return printTableAccess(entries)
+}
+
+// getAccessTargets returns the Resources and Folders to report, all of them if neither resourceID nor folderID is set
+func getAccessTargets(ctx context.Context, client *api.Client, resourceID, folderID string) ([]accessTarget, error) {
+	folders, err := client.GetFolders(ctx, &api.GetFoldersOptions{
+		ContainPermissions: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Listing Folders: %w", err)
+	}
+	folderPaths := folder.GetFolderPaths(folders)
+
+	targets := []accessTarget{}
+	if resourceID == "" {
+		for _, f := range folders {
+			if folderID == "" || f.ID == folderID {
+				targets = append(targets, accessTarget{
+					entityType:  "folder",
+					id:          f.ID,
+					path:        folderPaths[f.ID],
+					permissions: f.Permissions,
+				})
+			}
+		}
+	}
+	if folderID != "" {
+		return targets, nil
+	}
+
+	opts := &api.GetResourcesOptions{}
+	if resourceID != "" {
+		opts.FilterHasID = []string{resourceID}
+	}
+	resources, err := client.GetResources(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Resources: %w", err)
+	}
+	decrypted, err := resource.DecryptResourcesParallel(ctx, client, resources, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Resource Index does not contain all Permissions, so they are fetched per Resource
+	resourceTargets := make([]accessTarget, len(decrypted))
+	targetIndex := make(map[string]int, len(decrypted))
+	for i := range decrypted {
+		targetIndex[decrypted[i].Resource.ID] = i
+		resourceTargets[i] = accessTarget{
+			entityType: "resource",
+			id:         decrypted[i].Resource.ID,
+			path:       resource.ResourcePath(folderPaths, decrypted[i]),
+		}
+	}
+	errs := resource.ForEachParallel(ctx, decrypted, int(viper.GetUint("workers")), func(ctx context.Context, d resource.DecryptedResource) error {
+		i := targetIndex[d.Resource.ID]
+		permissions, err := client.GetResourcePermissions(ctx, d.Resource.ID)
+		if err != nil {
+			return fmt.Errorf("Getting Permissions of %v: %w", resourceTargets[i].path, err)
+		}
+		resourceTargets[i].permissions = permissions
+		return nil
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(targets, resourceTargets...), nil
+}
+
+// expandAccess turns the Permissions of all Targets into Entries per User, expanding Groups to their Members
+func expandAccess(targets []accessTarget, users []api.User, groups []api.Group) []AccessEntry {
+	usersByID := make(map[string]api.User, len(users))
+	for _, u := range users {
+		usersByID[u.ID] = u
+	}
+	groupsByID := make(map[string]api.Group, len(groups))
+	for _, g := range groups {
+		groupsByID[g.ID] = g
+	}
+
+	entries := []AccessEntry{}
+	add := func(t accessTarget, userID string, pType int, via string) {
+		entry := AccessEntry{
+			EntityType: t.entityType,
+			EntityID:   t.id,
+			Path:       t.path,
+			UserID:     userID,
+			Username:   userID,
+			Permission: util.PermissionTypeName(pType),
+			Via:        via,
+		}
+		// Users missing from the User List (e.g. deleted ones) are shown by their ID
+		if u, ok := usersByID[userID]; ok {
+			entry.Username = u.Username
+			if u.Profile != nil {
+				entry.FullName = u.Profile.FirstName + " " + u.Profile.LastName
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, t := range targets {
+		for _, p := range t.permissions {
+			switch p.ARO {
+			case "User":
+				add(t, p.AROForeignKey, p.Type, "direct")
+			case "Group":
+				g, ok := groupsByID[p.AROForeignKey]
+				if !ok {
+					// Groups missing from the Group List can't be expanded, they are shown by their ID
+					add(t, "", p.Type, p.AROForeignKey)
+					continue
+				}
+				for _, m := range g.GroupUsers {
+					add(t, m.UserID, p.Type, g.Name)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Path != entries[j].Path {
+			return entries[i].Path < entries[j].Path
+		}
+		return entries[i].Username < entries[j].Username
+	})
+	return entries
+}
+
+func printCSVAccess(entries []AccessEntry) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write([]string{"EntityType", "EntityID", "Path", "UserID", "Username", "FullName", "Permission", "Via"})
+	for _, e := range entries {
+		w.Write([]string{e.EntityType, e.EntityID, e.Path, e.UserID, e.Username, e.FullName, e.Permission, e.Via})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func printTableAccess(entries []AccessEntry) error {
+	data := pterm.TableData{{"Type", "Path", "Username", "FullName", "Permission", "Via"}}
+	for _, e := range entries {
+		data = append(data, []string{
+			e.EntityType,
+			shellescape.StripUnsafe(e.Path),
+			shellescape.StripUnsafe(e.Username),
+			shellescape.StripUnsafe(e.FullName),
+			e.Permission,
+			shellescape.StripUnsafe(e.Via),
+		})
+	}
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	return nil
+}
@@ -0,0 +1,382 @@
+package resource
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+//go:embed audit.html
+var auditHTMLTemplate string
+
+//go:embed commonpasswords.txt
+var commonPasswordsData string
+
+var commonPasswords = func() map[string]bool {
+	m := map[string]bool{}
+	for _, p := range strings.Fields(commonPasswordsData) {
+		m[p] = true
+	}
+	return m
+}()
+
+// leetReplacer undoes common Character Substitutions like "p@ssw0rd"
+var leetReplacer = strings.NewReplacer("@", "a", "4", "a", "3", "e", "1", "i", "!", "i", "0", "o", "$", "s", "5", "s", "7", "t")
+
+// ResourceAuditCmd Audits the Passwords of all Passbolt Resources
+var ResourceAuditCmd = &cobra.Command{
+	Use:   "passwords",
+	Short: "Audits the Passwords of Passbolt Resources",
+	Long: `Decrypts the Passwords of all Resources you have Access to and reports empty, weak and reused Passwords,
+Passwords which have not been changed for a long Time and expired or soon expiring Resources.
+With --breachDb the Passwords are also checked against a downloaded Have I Been Pwned SHA-1 Hash List,
+either the sorted "HASH:COUNT" File or a Directory of Range Files ("ABCDE.txt"). This happens entirely offline.
+Passwords based on a common Password like "Password123!" are reported as weak regardless of their Entropy.
+The Report never contains the Passwords themselves.`,
+	Aliases: []string{"password"},
+	Args:    cobra.NoArgs,
+	RunE:    ResourceAudit,
+}
+
+func init() {
+	ResourceAuditCmd.Flags().String("filter", "", "CEL expression selecting the Resources to audit, the same Variables as in \"list resource --filter\" can be used")
+	ResourceAuditCmd.Flags().String("format", "table", "Output Format: table, json or html")
+	ResourceAuditCmd.Flags().Float64("minEntropy", 60, "Passwords with a lower estimated Entropy in Bits are reported as weak")
+	ResourceAuditCmd.Flags().String("maxAge", "1y", "Passwords of Resources not modified for longer are reported as old, 0 disables this")
+	ResourceAuditCmd.Flags().String("expiringWithin", "30d", "Resources expiring within this Duration are reported as expiring")
+	ResourceAuditCmd.Flags().String("breachDb", "", "Have I Been Pwned SHA-1 Hash File or Directory of Range Files to check for breached Passwords")
+}
+
+// AuditSummary counts the Resources with each Issue
+type AuditSummary struct {
+	Total    int `json:"total"`
+	Empty    int `json:"empty"`
+	Weak     int `json:"weak"`
+	Reused   int `json:"reused"`
+	Old      int `json:"old"`
+	Expired  int `json:"expired"`
+	Expiring int `json:"expiring"`
+	Breached int `json:"breached"`
+}
+
+// AuditFinding lists the Issues of a single Resource
+type AuditFinding struct {
+	ID                string     `json:"id"`
+	Name              string     `json:"name"`
+	Username          string     `json:"username"`
+	URI               string     `json:"uri"`
+	Entropy           float64    `json:"entropy"`
+	ReusedBy          []string   `json:"reused_by,omitempty"`
+	Breached          int        `json:"breached,omitempty"`
+	ModifiedTimestamp *time.Time `json:"modified_timestamp,omitempty"`
+	ExpiredTimestamp  *time.Time `json:"expired_timestamp,omitempty"`
+	Issues            []string   `json:"issues"`
+}
+
+// AuditReport is the Result of auditing Passwords
+type AuditReport struct {
+	Generated time.Time      `json:"generated"`
+	Summary   AuditSummary   `json:"summary"`
+	Findings  []AuditFinding `json:"findings"`
+}
+
+type auditConfig struct {
+	minEntropy     float64
+	maxAge         time.Duration
+	expiringWithin time.Duration
+	breachDB       *breachDB
+}
+
+func ResourceAudit(cmd *cobra.Command, args []string) error {
+	filter, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	switch format {
+	case "table", "json", "html":
+	default:
+		return fmt.Errorf("Unknown Format %q, use table, json or html", format)
+	}
+	minEntropy, err := cmd.Flags().GetFloat64("minEntropy")
+	if err != nil {
+		return err
+	}
+	maxAgeInput, err := cmd.Flags().GetString("maxAge")
+	if err != nil {
+		return err
+	}
+	maxAge, err := util.ParseDuration(maxAgeInput)
+	if err != nil {
+		return fmt.Errorf("Parsing maxAge: %w", err)
+	}
+	expiringWithinInput, err := cmd.Flags().GetString("expiringWithin")
+	if err != nil {
+		return err
+	}
+	expiringWithin, err := util.ParseDuration(expiringWithinInput)
+	if err != nil {
+		return fmt.Errorf("Parsing expiringWithin: %w", err)
+	}
+	breachDBPath, err := cmd.Flags().GetString("breachDb")
+	if err != nil {
+		return err
+	}
+
+	config := auditConfig{
+		minEntropy:     minEntropy,
+		maxAge:         maxAge,
+		expiringWithin: expiringWithin,
+	}
+	if breachDBPath != "" {
+		config.breachDB, err = openBreachDB(breachDBPath)
+		if err != nil {
+			return err
+		}
+		defer config.breachDB.Close()
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	resources, err := client.GetResources(ctx, &api.GetResourcesOptions{
+		ContainSecret: true,
+	})
+	if err != nil {
+		return fmt.Errorf("Listing Resource: %w", err)
+	}
+
+	decrypted, err := decryptResourcesParallel(ctx, client, resources, true)
+	if err != nil {
+		return err
+	}
+
+	if filter != "" {
+		decrypted, err = filterDecryptedResources(decrypted, filter, ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := auditPasswords(decrypted, config, time.Now())
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		jsonReport, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonReport))
+		return nil
+	case "html":
+		tmpl, err := template.New("audit").Parse(auditHTMLTemplate)
+		if err != nil {
+			return fmt.Errorf("Parsing HTML Template: %w", err)
+		}
+		return tmpl.Execute(os.Stdout, report)
+	}
+	return printTableAudit(report)
+}
+
+// auditPasswords checks all Resources and returns a Report containing those with Issues
+func auditPasswords(decrypted []DecryptedResource, config auditConfig, now time.Time) (AuditReport, error) {
+	report := AuditReport{
+		Generated: now,
+		Summary:   AuditSummary{Total: len(decrypted)},
+		Findings:  []AuditFinding{},
+	}
+
+	usedBy := map[string][]string{}
+	for _, d := range decrypted {
+		if d.Password != "" {
+			usedBy[d.Password] = append(usedBy[d.Password], d.Resource.ID)
+		}
+	}
+
+	// Every distinct Password only needs to be looked up once
+	breached := map[string]int{}
+	if config.breachDB != nil {
+		for password := range usedBy {
+			count, err := config.breachDB.Count(password)
+			if err != nil {
+				return report, err
+			}
+			breached[password] = count
+		}
+	}
+
+	for _, d := range decrypted {
+		finding := AuditFinding{
+			ID:       d.Resource.ID,
+			Name:     d.Name,
+			Username: d.Username,
+			URI:      d.URI,
+			Entropy:  math.Round(PasswordEntropy(d.Password)*10) / 10,
+		}
+
+		if d.Password == "" {
+			finding.Issues = append(finding.Issues, "Empty")
+			report.Summary.Empty++
+		} else if IsCommonPassword(d.Password) {
+			finding.Issues = append(finding.Issues, "Weak (common Password)")
+			report.Summary.Weak++
+		} else if finding.Entropy < config.minEntropy {
+			finding.Issues = append(finding.Issues, fmt.Sprintf("Weak (%.0f Bits)", finding.Entropy))
+			report.Summary.Weak++
+		}
+
+		if ids := usedBy[d.Password]; len(ids) > 1 {
+			for _, id := range ids {
+				if id != d.Resource.ID {
+					finding.ReusedBy = append(finding.ReusedBy, id)
+				}
+			}
+			finding.Issues = append(finding.Issues, fmt.Sprintf("Reused (%d Resources)", len(ids)))
+			report.Summary.Reused++
+		}
+
+		if count := breached[d.Password]; count != 0 {
+			finding.Breached = count
+			finding.Issues = append(finding.Issues, fmt.Sprintf("Breached (%d Times)", count))
+			report.Summary.Breached++
+		}
+
+		if d.Resource.Modified != nil {
+			modified := d.Resource.Modified.Time
+			finding.ModifiedTimestamp = &modified
+			if config.maxAge > 0 && now.Sub(modified) > config.maxAge {
+				finding.Issues = append(finding.Issues, fmt.Sprintf("Old (%d Days)", int(now.Sub(modified).Hours()/24)))
+				report.Summary.Old++
+			}
+		}
+
+		if d.Resource.Expired != nil && !d.Resource.Expired.IsZero() {
+			expired := d.Resource.Expired.Time
+			finding.ExpiredTimestamp = &expired
+			if !expired.After(now) {
+				finding.Issues = append(finding.Issues, "Expired")
+				report.Summary.Expired++
+			} else if expired.Sub(now) <= config.expiringWithin {
+				finding.Issues = append(finding.Issues, fmt.Sprintf("Expiring (%v)", expired.Format(time.DateOnly)))
+				report.Summary.Expiring++
+			}
+		}
+
+		if len(finding.Issues) != 0 {
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+	return report, nil
+}
+
+// IsCommonPassword reports whether a Password is a common Password, ignoring Case, common Character Substitutions
+// and Digits or Symbols appended to it, so "Password123!" and "P@ssw0rd" are both found.
+func IsCommonPassword(password string) bool {
+	lower := strings.ToLower(password)
+	base := strings.TrimRightFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, candidate := range []string{lower, base, leetReplacer.Replace(lower), leetReplacer.Replace(base)} {
+		if commonPasswords[candidate] {
+			return true
+		}
+	}
+	return false
+}
+
+// PasswordEntropy estimates the Entropy of a Password in Bits from its Length and the Character Classes it uses.
+// Repeated Characters only count once, so "aaaaaaaa" is not mistaken for a strong Password.
+func PasswordEntropy(password string) float64 {
+	var lower, upper, digit, symbol, other bool
+	unique := map[rune]bool{}
+	for _, r := range password {
+		unique[r] = true
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	pool := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{
+		{lower, 26},
+		{upper, 26},
+		{digit, 10},
+		{symbol, 33},
+		{other, 100},
+	} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	// Every additional Repetition of a Character adds only a single Bit
+	length := len([]rune(password))
+	return float64(len(unique))*math.Log2(float64(pool)) + float64(length-len(unique))
+}
+
+func printTableAudit(report AuditReport) error {
+	s := report.Summary
+	pterm.DefaultTable.WithHasHeader().WithData(pterm.TableData{
+		{"Total", "Empty", "Weak", "Reused", "Breached", "Old", "Expired", "Expiring"},
+		{
+			fmt.Sprint(s.Total), fmt.Sprint(s.Empty), fmt.Sprint(s.Weak), fmt.Sprint(s.Reused), fmt.Sprint(s.Breached),
+			fmt.Sprint(s.Old), fmt.Sprint(s.Expired), fmt.Sprint(s.Expiring),
+		},
+	}).Render()
+
+	if len(report.Findings) == 0 {
+		fmt.Println("No Issues found")
+		return nil
+	}
+
+	data := pterm.TableData{{"ID", "Name", "Username", "URI", "Issues"}}
+	for _, f := range report.Findings {
+		data = append(data, []string{
+			f.ID,
+			shellescape.StripUnsafe(f.Name),
+			shellescape.StripUnsafe(f.Username),
+			shellescape.StripUnsafe(f.URI),
+			strings.Join(f.Issues, ", "),
+		})
+	}
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	return nil
+}
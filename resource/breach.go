@@ -0,0 +1,152 @@
+package resource
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// breachDB looks up Passwords in a locally downloaded Have I Been Pwned SHA-1 Hash List.
+// This is either a single File of "HASH:COUNT" Lines sorted by Hash,
+// or a Directory of k-Anonymity Range Files named after the first 5 Characters of the Hash ("ABCDE.txt") with "SUFFIX:COUNT" Lines.
+type breachDB struct {
+	dir  string
+	file *os.File
+	size int64
+}
+
+func openBreachDB(path string) (*breachDB, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("Opening Breach Database: %w", err)
+	}
+	if info.IsDir() {
+		return &breachDB{dir: path}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Opening Breach Database: %w", err)
+	}
+	return &breachDB{file: file, size: info.Size()}, nil
+}
+
+func (db *breachDB) Close() error {
+	if db.file != nil {
+		return db.file.Close()
+	}
+	return nil
+}
+
+// Count returns how often the Password appears in the Breach Database, 0 if it does not
+func (db *breachDB) Count(password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	if db.dir != "" {
+		return db.countInRange(hash)
+	}
+	return db.countInFile(hash)
+}
+
+func (db *breachDB) countInRange(hash string) (int, error) {
+	file, err := os.Open(filepath.Join(db.dir, hash[:5]+".txt"))
+	if err != nil {
+		return 0, fmt.Errorf("Opening Range File: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		suffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if ok && strings.EqualFold(suffix, hash[5:]) {
+			return parseBreachCount(count)
+		}
+	}
+	return 0, scanner.Err()
+}
+
+// countInFile does a binary Search over the Byte Offsets of the sorted File,
+// so even the full List with close to a Billion Lines needs only a few Reads.
+func (db *breachDB) countInFile(hash string) (int, error) {
+	lo, hi := int64(0), db.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, err := db.lineStart(mid)
+		if err != nil {
+			return 0, err
+		}
+		if start >= hi {
+			hi = mid
+			continue
+		}
+
+		line, next, err := db.readLine(start)
+		if err != nil {
+			return 0, err
+		}
+		lineHash, count, _ := strings.Cut(line, ":")
+		switch strings.Compare(strings.ToUpper(lineHash), hash) {
+		case 0:
+			return parseBreachCount(count)
+		case -1:
+			lo = next
+		default:
+			hi = mid
+		}
+	}
+	return 0, nil
+}
+
+// lineStart returns the Offset of the first Line starting at or after offset
+func (db *breachDB) lineStart(offset int64) (int64, error) {
+	if offset == 0 {
+		return 0, nil
+	}
+	buf := make([]byte, 128)
+	pos := offset - 1
+	for pos < db.size {
+		n, err := db.file.ReadAt(buf, pos)
+		if i := strings.IndexByte(string(buf[:n]), '\n'); i != -1 {
+			return pos + int64(i) + 1, nil
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("Reading Breach Database: %w", err)
+		}
+		pos += int64(n)
+	}
+	return db.size, nil
+}
+
+// readLine returns the Line at offset and the Offset of the next Line
+func (db *breachDB) readLine(offset int64) (string, int64, error) {
+	buf := make([]byte, 128)
+	n, err := db.file.ReadAt(buf, offset)
+	if err != nil && err != io.EOF {
+		return "", 0, fmt.Errorf("Reading Breach Database: %w", err)
+	}
+	line := string(buf[:n])
+	next := offset + int64(n)
+	if i := strings.IndexByte(line, '\n'); i != -1 {
+		line = line[:i]
+		next = offset + int64(i) + 1
+	}
+	return strings.TrimSpace(line), next, nil
+}
+
+func parseBreachCount(count string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil {
+		return 0, fmt.Errorf("Invalid Count in Breach Database: %w", err)
+	}
+	return n, nil
+}
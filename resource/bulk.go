@@ -0,0 +1,150 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// addBulkFlags adds the Flags to apply a Command to all Resources matching a CEL Filter instead of a single ID
+func addBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().String("filter", "", "CEL expression selecting all Resources to apply this to instead of a single ID.\n"+
+		"The same Variables as in \"list resource --filter\" can be used, e.g. --filter 'URI.contains(\"prod-db\")'")
+	cmd.Flags().BoolP("yes", "y", false, "Don't ask for Confirmation before applying this to all Resources matching --filter")
+}
+
+// getIDOrFilter returns either the ID of a single Resource or the --filter for selecting multiple Resources
+func getIDOrFilter(cmd *cobra.Command, args []string) (id, filter string, yes bool, err error) {
+	filter, err = cmd.Flags().GetString("filter")
+	if err != nil {
+		return "", "", false, err
+	}
+	yes, err = cmd.Flags().GetBool("yes")
+	if err != nil {
+		return "", "", false, err
+	}
+
+	if filter == "" {
+		id, err = util.GetIDArg(cmd, args)
+		return id, "", yes, err
+	}
+	if cmd.Flags().Changed("id") || len(args) != 0 {
+		return "", "", false, fmt.Errorf("Either an ID or --filter can be given, not both")
+	}
+	return "", filter, yes, nil
+}
+
+// selectResources returns all Resources matching the CEL Filter, which may be none
+func selectResources(ctx context.Context, client *api.Client, filter string) ([]DecryptedResource, error) {
+	needSecrets, err := util.CELExpressionReferencesFields(filter, []string{"Password", "Description"}, CelEnvOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("Parsing filter: %w", err)
+	}
+
+	resources, err := client.GetResources(ctx, &api.GetResourcesOptions{
+		ContainSecret: needSecrets,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Listing Resource: %w", err)
+	}
+
+	decrypted, err := decryptResourcesParallel(ctx, client, resources, needSecrets)
+	if err != nil {
+		return nil, err
+	}
+	return matchDecryptedResources(decrypted, filter, ctx)
+}
+
+// confirmBulk previews the selected Resources and asks for Confirmation
+func confirmBulk(action string, resources []DecryptedResource, yes bool) error {
+	data := pterm.TableData{{"ID", "Name", "Username", "URI"}}
+	for _, d := range resources {
+		data = append(data, []string{
+			d.Resource.ID,
+			shellescape.StripUnsafe(d.Name),
+			shellescape.StripUnsafe(d.Username),
+			shellescape.StripUnsafe(d.URI),
+		})
+	}
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+
+	return util.Confirm(fmt.Sprintf("%v %d Resources?", action, len(resources)), yes)
+}
+
+// ForEachParallel applies op to all Resources using numWorkers in parallel and returns the Error of each one.
+func ForEachParallel(ctx context.Context, resources []DecryptedResource, numWorkers int, op func(ctx context.Context, d DecryptedResource) error) []error {
+	if len(resources) < numWorkers {
+		numWorkers = len(resources)
+	}
+
+	errs := make([]error, len(resources))
+	jobs := make(chan int, len(resources))
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				errs[idx] = op(ctx, resources[idx])
+			}
+		}()
+	}
+	for i := range resources {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return errs
+}
+
+// runBulk applies op to all Resources using numWorkers in parallel and prints the Result of each one.
+func runBulk(ctx context.Context, resources []DecryptedResource, numWorkers int, op func(ctx context.Context, d DecryptedResource) error) error {
+	errs := ForEachParallel(ctx, resources, numWorkers, op)
+
+	failed := 0
+	data := pterm.TableData{{"ID", "Name", "Result"}}
+	for i, d := range resources {
+		result := "OK"
+		if errs[i] != nil {
+			result = "Error: " + errs[i].Error()
+			failed++
+		}
+		data = append(data, []string{d.Resource.ID, shellescape.StripUnsafe(d.Name), result})
+	}
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+
+	if failed != 0 {
+		return fmt.Errorf("%d of %d Resources failed", failed, len(resources))
+	}
+	return nil
+}
+
+// bulk selects all Resources matching filter, asks for Confirmation and applies op to them in parallel
+func bulk(ctx context.Context, client *api.Client, action, filter string, yes bool, op func(ctx context.Context, d DecryptedResource) error) error {
+	return bulkWithWorkers(ctx, client, action, filter, yes, int(viper.GetUint("workers")), op)
+}
+
+// bulkWithWorkers is the same as bulk but limits the Number of Resources processed at once
+func bulkWithWorkers(ctx context.Context, client *api.Client, action, filter string, yes bool, numWorkers int, op func(ctx context.Context, d DecryptedResource) error) error {
+	resources, err := selectResources(ctx, client, filter)
+	if err != nil {
+		return err
+	}
+	if len(resources) == 0 {
+		fmt.Println("No Resources match the filter")
+		return nil
+	}
+
+	err = confirmBulk(action, resources, yes)
+	if err != nil {
+		return err
+	}
+	return runBulk(ctx, resources, numWorkers, op)
+}
@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// CacheKind is the Name of the Resource Metadata Cache
+const CacheKind = "resources"
+
+// FetchResources fetches all Resources for the Metadata Cache.
+// The Passbolt API can't filter Resources by their Modified Timestamp, so the full Resource List is downloaded on every refresh,
+// but only Resources which are new or whose Modified Timestamp changed are decrypted again.
+// The Changes compared to the cached Resources are recorded in the local History.
+func FetchResources(ctx context.Context, client *api.Client, cached []DecryptedResource) ([]DecryptedResource, error) {
+	resources, err := client.GetResources(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Resources: %w", err)
+	}
+
+	unchanged := make(map[string]DecryptedResource, len(cached))
+	for _, d := range cached {
+		unchanged[d.Resource.ID] = d
+	}
+
+	result := make([]DecryptedResource, 0, len(resources))
+	changed := []api.Resource{}
+	for _, resource := range resources {
+		if d, ok := unchanged[resource.ID]; ok && sameTime(d.Resource.Modified, resource.Modified) {
+			d.Resource = stripResource(resource)
+			result = append(result, d)
+			continue
+		}
+		changed = append(changed, resource)
+	}
+
+	decrypted, err := decryptResourcesParallel(ctx, client, changed, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range decrypted {
+		d.Resource = stripResource(d.Resource)
+		result = append(result, d)
+	}
+
+	// Without a previous Cache every Resource would look new
+	if cached != nil {
+		recordLocalHistory(client, cached, result)
+	}
+	return result, nil
+}
+
+// getResourcesAndFolderPaths returns all decrypted Resources and Folder Paths, from the Metadata Cache if it is enabled.
+func getResourcesAndFolderPaths(ctx context.Context, client *api.Client) ([]DecryptedResource, map[string]string, error) {
+	folders, err := folder.GetFolders(ctx, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var decrypted []DecryptedResource
+	if cache.Enabled() {
+		decrypted, err = cache.List(ctx, cache.FromClient(client), CacheKind, FetchResources)
+	} else {
+		decrypted, err = FetchResources(ctx, client, nil)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return decrypted, folder.GetFolderPaths(folders), nil
+}
+
+// stripResource removes encrypted Data which is not needed anymore once the Resource is decrypted
+func stripResource(resource api.Resource) api.Resource {
+	resource.Metadata = ""
+	resource.Secrets = nil
+	return resource
+}
+
+func sameTime(a, b *api.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(b.Time)
+}
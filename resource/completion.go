@@ -0,0 +1,35 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// CompleteResourceIDs completes Resource IDs with their Names as Description
+var CompleteResourceIDs = cache.CompleteIDs("resource", fetchResourceCompletions)
+
+var completeResourceArg = cache.CompleteArgIDs("resource", fetchResourceCompletions)
+
+func fetchResourceCompletions(ctx context.Context, client *api.Client) ([]cache.Completion, error) {
+	resources, err := client.GetResources(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Resources: %w", err)
+	}
+
+	decrypted, err := decryptResourcesParallel(ctx, client, resources, false)
+	if err != nil {
+		return nil, err
+	}
+
+	completions := make([]cache.Completion, len(decrypted))
+	for i, d := range decrypted {
+		completions[i] = cache.Completion{
+			ID:   d.Resource.ID,
+			Name: d.Name,
+		}
+	}
+	return completions, nil
+}
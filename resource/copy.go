@@ -0,0 +1,95 @@
+package resource
+
+import (
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// CopyResourceCmd Copies a Passbolt Resource
+var CopyResourceCmd = &cobra.Command{
+	Use:   "resource [id]",
+	Short: "Copies a Passbolt Resource",
+	Long: `Copies a Passbolt Resource including Type specific Fields (e.g. TOTP, Custom Fields) and Returns the new Resources ID.
+The Copy is created with default Permissions (you are the Owner) unless --copyPermissions is set.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeResourceArg,
+	RunE:              CopyResource,
+}
+
+func init() {
+	CopyResourceCmd.Flags().String("id", "", "id, name or path of Resource to Copy")
+	CopyResourceCmd.Flags().StringP("name", "n", "", "Name of the Copy, defaults to the Name of the Original")
+	CopyResourceCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to create the Copy, as id or path (e.g. Infra/AWS), defaults to the Folder of the Original")
+	CopyResourceCmd.Flags().Bool("copyPermissions", false, "Give the Copy the same Permissions as the Original")
+	CopyResourceCmd.RegisterFlagCompletionFunc("id", CompleteResourceIDs)
+	CopyResourceCmd.RegisterFlagCompletionFunc("folderParentID", folder.CompleteFolderIDs)
+}
+
+func CopyResource(cmd *cobra.Command, args []string) error {
+	id, err := util.GetIDArg(cmd, args)
+	if err != nil {
+		return err
+	}
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		return err
+	}
+	folderParentID, err := cmd.Flags().GetString("folderParentID")
+	if err != nil {
+		return err
+	}
+	copyPermissions, err := cmd.Flags().GetBool("copyPermissions")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	id, err = ResolveResourceID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Resource: %w", err)
+	}
+	resource, err := client.GetResource(ctx, id)
+	if err != nil {
+		return fmt.Errorf("Getting Resource: %w", err)
+	}
+
+	if cmd.Flags().Changed("folderParentID") {
+		folderParentID, err = folder.ResolveFolderID(ctx, client, folderParentID)
+		if err != nil {
+			return fmt.Errorf("Resolving Folder: %w", err)
+		}
+	} else {
+		folderParentID = resource.FolderParentID
+	}
+
+	newID, err := util.CopyResource(ctx, client, *resource, folderParentID, name)
+	if err != nil {
+		return err
+	}
+	// The Copy exists even if its Permissions can't be copied
+	fmt.Printf("ResourceID: %v\n", newID)
+
+	if copyPermissions {
+		permissions, err := client.GetResourcePermissions(ctx, id)
+		if err != nil {
+			return fmt.Errorf("Getting Permissions: %w", err)
+		}
+		err = shareResource(ctx, client, newID, util.ShareSpecFromPermissions(permissions, false), nil)
+		if err != nil {
+			return fmt.Errorf("Copying Permissions to %v: %w", newID, err)
+		}
+	}
+	return nil
+}
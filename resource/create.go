@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/passbolt/go-passbolt-cli/folder"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/helper"
 	"github.com/spf13/cobra"
@@ -22,11 +23,13 @@ func init() {
 	ResourceCreateCmd.Flags().StringP("username", "u", "", "Resource Username")
 	ResourceCreateCmd.Flags().String("uri", "", "Resource URI")
 	ResourceCreateCmd.Flags().StringP("password", "p", "", "Resource Password")
+	ResourceCreateCmd.Flags().Bool("generate", false, "Generate the Resource Password, using the Password Policy of the Server if available")
 	ResourceCreateCmd.Flags().StringP("description", "d", "", "Resource Description")
-	ResourceCreateCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to create the Resource")
-	ResourceCreateCmd.Flags().String("expiry", "", "Expiry as RFC3339 (e.g. 2025-12-31T23:59:59Z) or Go duration (e.g. 48h, 30m)")
+	ResourceCreateCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to create the Resource, as id or path (e.g. Infra/AWS)")
+	ResourceCreateCmd.RegisterFlagCompletionFunc("folderParentID", folder.CompleteFolderIDs)
+	ResourceCreateCmd.Flags().String("expiry", "", "Expiry as RFC3339 (e.g. 2025-12-31T23:59:59Z), date (e.g. 2025-12-31), duration (e.g. 90d, 1w2d) or phrase (e.g. end-of-quarter)")
 	ResourceCreateCmd.MarkFlagRequired("name")
-	ResourceCreateCmd.MarkFlagRequired("password")
+	util.AddGeneratorFlags(ResourceCreateCmd)
 }
 
 func ResourceCreate(cmd *cobra.Command, args []string) error {
@@ -54,6 +57,13 @@ func ResourceCreate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	generate, err := cmd.Flags().GetBool("generate")
+	if err != nil {
+		return err
+	}
+	if generate == (password != "") {
+		return fmt.Errorf("Either --password or --generate is required")
+	}
 
 	expiry, err := cmd.Flags().GetString("expiry")
 	if err != nil {
@@ -75,6 +85,22 @@ func ResourceCreate(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	folderParentID, err = folder.ResolveFolderID(ctx, client, folderParentID)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
+	if generate {
+		policy, err := util.GetGeneratorPolicy(ctx, cmd, client)
+		if err != nil {
+			return err
+		}
+		password, err = util.GeneratePassword(policy)
+		if err != nil {
+			return fmt.Errorf("Generating Password: %w", err)
+		}
+	}
+
 	id, err := helper.CreateResource(
 		ctx,
 		client,
@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/passbolt/go-passbolt-cli/util"
@@ -9,22 +10,26 @@ import (
 
 // ResourceDeleteCmd Deletes a Resource
 var ResourceDeleteCmd = &cobra.Command{
-	Use:   "resource",
-	Short: "Deletes a Passbolt Resource",
-	Long:  `Deletes a Passbolt Resource`,
-	RunE:  ResourceDelete,
+	Use:               "resource [id]",
+	Short:             "Deletes a Passbolt Resource",
+	Long:              `Deletes a Passbolt Resource`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeResourceArg,
+	RunE:              ResourceDelete,
+}
+
+func init() {
+	ResourceDeleteCmd.Flags().String("id", "", "id, name or path of Resource to Delete")
+	ResourceDeleteCmd.RegisterFlagCompletionFunc("id", CompleteResourceIDs)
+	addBulkFlags(ResourceDeleteCmd)
 }
 
 func ResourceDelete(cmd *cobra.Command, args []string) error {
-	resourceID, err := cmd.Flags().GetString("id")
+	resourceID, filter, yes, err := getIDOrFilter(cmd, args)
 	if err != nil {
 		return err
 	}
 
-	if resourceID == "" {
-		return fmt.Errorf("No ID to Delete Provided")
-	}
-
 	ctx, cancel := util.GetContext()
 	defer cancel()
 
@@ -35,6 +40,17 @@ func ResourceDelete(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	if filter != "" {
+		return bulk(ctx, client, "Delete", filter, yes, func(ctx context.Context, d DecryptedResource) error {
+			return client.DeleteResource(ctx, d.Resource.ID)
+		})
+	}
+
+	resourceID, err = ResolveResourceID(ctx, client, resourceID)
+	if err != nil {
+		return fmt.Errorf("Resolving Resource: %w", err)
+	}
+
 	err = client.DeleteResource(ctx, resourceID)
 	if err != nil {
 		return fmt.Errorf("Deleting Resource: %w", err)
@@ -3,10 +3,10 @@ package resource
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 
+	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/api"
 )
 
@@ -17,7 +17,7 @@ func SetResourceExpiry(ctx context.Context, client *api.Client, id string, expir
 	}
 
 	// Safety: ensure the resource id is a UUID to avoid unsafe URL construction
-	if !isUUID(id) {
+	if !util.IsUUID(id) {
 		return fmt.Errorf("invalid resource id: %q", id)
 	}
 
@@ -58,43 +58,37 @@ func SetResourceExpiry(ctx context.Context, client *api.Client, id string, expir
 	return nil
 }
 
-// ParseExpiry accepts either an absolute time (ISO8601/RFC3339) or a human duration like "7d", "12h", "30m", or combinations like "1w2d3h".
-// It returns an ISO8601 (RFC3339) timestamp string in UTC suitable for the API.
-func ParseExpiry(input string) (string, error) {
-	if input == "" {
-		return "", nil
+// expiredTime returns the Expiry of a Resource, the zero Time if it has none
+func expiredTime(resource api.Resource) time.Time {
+	if resource.Expired == nil {
+		return time.Time{}
 	}
-	// Try absolute timestamp first
-	if t, err := tryParseAbsoluteTime(input); err == nil {
-		return t.UTC().Format(time.RFC3339), nil
-	}
-	// Fallback to human duration(s)
-	d, err := time.ParseDuration(input)
-	if err != nil {
-		return "", fmt.Errorf("invalid expiry value %q: %w", input, err)
-	}
-	return time.Now().UTC().Add(d).Format(time.RFC3339), nil
+	return resource.Expired.Time
 }
 
-func tryParseAbsoluteTime(s string) (time.Time, error) {
-	// Try RFC3339 variants only (avoid nonstandard timestamp formats)
-	layouts := []string{
-		time.RFC3339,
-		time.RFC3339Nano,
-	}
-	var lastErr error
-	for _, layout := range layouts {
-		if t, err := time.Parse(layout, s); err == nil {
-			return t, nil
-		} else {
-			lastErr = err
+// filterExpiringResources returns the Resources which have an Expiry before now + within, including already expired ones
+func filterExpiringResources(resources []DecryptedResource, within time.Duration) []DecryptedResource {
+	deadline := time.Now().Add(within)
+	filtered := []DecryptedResource{}
+	for _, d := range resources {
+		expired := expiredTime(d.Resource)
+		if !expired.IsZero() && !expired.After(deadline) {
+			filtered = append(filtered, d)
 		}
 	}
-	return time.Time{}, lastErr
+	return filtered
 }
 
-// isUUID performs a basic UUID validation in canonical 8-4-4-4-12 hex format.
-func isUUID(s string) bool {
-	re := regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
-	return re.MatchString(s)
+// ParseExpiry accepts an absolute time (RFC3339 or a date like 2025-12-31), a duration like "7d", "3mo" or "1w2d3h",
+// or a phrase like "end-of-quarter" (see util.ParseRelativeTime).
+// It returns an ISO8601 (RFC3339) timestamp string in UTC suitable for the API.
+func ParseExpiry(input string) (string, error) {
+	if input == "" {
+		return "", nil
+	}
+	t, err := util.ParseRelativeTime(input, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("invalid expiry value: %w", err)
+	}
+	return t.UTC().Format(time.RFC3339), nil
 }
@@ -3,6 +3,7 @@ package resource
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/cel-go/cel"
 	"github.com/passbolt/go-passbolt-cli/util"
@@ -19,10 +20,47 @@ var CelEnvOptions = []cel.EnvOption{
 	cel.Variable("Description", cel.StringType),
 	cel.Variable("CreatedTimestamp", cel.TimestampType),
 	cel.Variable("ModifiedTimestamp", cel.TimestampType),
+	cel.Variable("Expired", cel.TimestampType),
+	cel.Variable("HasExpiry", cel.BoolType),
+	cel.Variable("IsExpired", cel.BoolType),
+}
+
+// resourceCelVars returns the CEL activation for a decrypted resource.
+// Expired is the zero Timestamp for Resources without Expiry, so "Expired < now" also matches those,
+// filters should use IsExpired or check HasExpiry first.
+func resourceCelVars(d DecryptedResource) map[string]any {
+	expired := expiredTime(d.Resource)
+	return map[string]any{
+		"ID":                d.Resource.ID,
+		"FolderParentID":    d.Resource.FolderParentID,
+		"Name":              d.Name,
+		"Username":          d.Username,
+		"URI":               d.URI,
+		"Password":          d.Password,
+		"Description":       d.Description,
+		"CreatedTimestamp":  d.Resource.Created.Time,
+		"ModifiedTimestamp": d.Resource.Modified.Time,
+		"Expired":           expired,
+		"HasExpiry":         !expired.IsZero(),
+		"IsExpired":         !expired.IsZero() && !expired.After(time.Now()),
+	}
 }
 
 // filterDecryptedResources filters already-decrypted resources by evaluating a CEL expression.
+// It fails if no Resource matches.
 func filterDecryptedResources(resources []DecryptedResource, celCmd string, ctx context.Context) ([]DecryptedResource, error) {
+	filtered, err := matchDecryptedResources(resources, celCmd, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf("No such Resources found with filter %v!", celCmd)
+	}
+	return filtered, nil
+}
+
+// matchDecryptedResources returns the already-decrypted resources matching a CEL expression, which may be none.
+func matchDecryptedResources(resources []DecryptedResource, celCmd string, ctx context.Context) ([]DecryptedResource, error) {
 	if celCmd == "" {
 		return resources, nil
 	}
@@ -34,17 +72,7 @@ func filterDecryptedResources(resources []DecryptedResource, celCmd string, ctx
 
 	filtered := []DecryptedResource{}
 	for _, d := range resources {
-		val, _, err := (*program).ContextEval(ctx, map[string]any{
-			"ID":                d.Resource.ID,
-			"FolderParentID":    d.Resource.FolderParentID,
-			"Name":              d.Name,
-			"Username":          d.Username,
-			"URI":               d.URI,
-			"Password":          d.Password,
-			"Description":       d.Description,
-			"CreatedTimestamp":  d.Resource.Created.Time,
-			"ModifiedTimestamp": d.Resource.Modified.Time,
-		})
+		val, _, err := (*program).ContextEval(ctx, resourceCelVars(d))
 
 		if err != nil {
 			return nil, err
@@ -54,9 +82,5 @@ func filterDecryptedResources(resources []DecryptedResource, celCmd string, ctx
 			filtered = append(filtered, d)
 		}
 	}
-
-	if len(filtered) == 0 {
-		return nil, fmt.Errorf("No such Resources found with filter %v!", celCmd)
-	}
 	return filtered, nil
 }
@@ -3,48 +3,50 @@ package resource
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
-	"time"
 
 	"al.essio.dev/pkg/shellescape"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/helper"
-	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // ResourceGetCmd Gets a Passbolt Resource
 var ResourceGetCmd = &cobra.Command{
-	Use:   "resource",
-	Short: "Gets a Passbolt Resource",
-	Long:  `Gets a Passbolt Resource`,
-	RunE:  ResourceGet,
+	Use:               "resource [id]",
+	Short:             "Gets a Passbolt Resource",
+	Long:              `Gets a Passbolt Resource`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeResourceArg,
+	RunE:              ResourceGet,
 }
 
 // ResourcePermissionCmd Gets Permissions for Passbolt Resource
 var ResourcePermissionCmd = &cobra.Command{
-	Use:     "permission",
-	Short:   "Gets Permissions for a Passbolt Resource",
-	Long:    `Gets Permissions for a Passbolt Resource`,
-	Aliases: []string{"permissions"},
-	RunE:    ResourcePermission,
+	Use:               "permission [id]",
+	Short:             "Gets Permissions for a Passbolt Resource",
+	Long:              `Gets Permissions for a Passbolt Resource`,
+	Aliases:           []string{"permissions"},
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeResourceArg,
+	RunE:              ResourcePermission,
 }
 
 func init() {
-	ResourceGetCmd.Flags().String("id", "", "id of Resource to Get")
+	ResourceGetCmd.Flags().String("id", "", "id, name or path of Resource to Get")
 
-	ResourceGetCmd.MarkFlagRequired("id")
+	ResourceGetCmd.RegisterFlagCompletionFunc("id", CompleteResourceIDs)
 
 	ResourceGetCmd.AddCommand(ResourcePermissionCmd)
-	ResourcePermissionCmd.Flags().String("id", "", "id of Resource to Get")
-	ResourcePermissionCmd.Flags().StringArrayP("column", "c", []string{"ID", "Aco", "AcoForeignKey", "Aro", "AroForeignKey", "Type"}, "Columns to return, possible Columns:\nID, Aco, AcoForeignKey, Aro, AroForeignKey, Type, CreatedTimestamp, ModifiedTimestamp")
+	ResourcePermissionCmd.Flags().String("id", "", "id, name or path of Resource to Get")
+	ResourcePermissionCmd.Flags().StringArrayP("column", "c", util.DefaultPermissionColumns, util.PermissionColumnsHelp)
+	ResourcePermissionCmd.Flags().String("filter", "", util.PermissionFilterHelp)
 
-	ResourcePermissionCmd.MarkFlagRequired("id")
+	ResourcePermissionCmd.RegisterFlagCompletionFunc("id", CompleteResourceIDs)
 }
 
 func ResourceGet(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -53,6 +55,20 @@ func ResourceGet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if viper.GetBool("offline") {
+		snapshot, _, err := LoadSnapshot()
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+
+		r, err := snapshot.FindResource(id)
+		if err != nil {
+			return fmt.Errorf("Resolving Resource: %w", err)
+		}
+		return printResource(r.Resource.FolderParentID, r.Name, r.Username, r.URI, r.Password, r.Description, jsonOutput)
+	}
+
 	ctx, cancel := util.GetContext()
 	defer cancel()
 
@@ -63,6 +79,11 @@ func ResourceGet(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	id, err = ResolveResourceID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Resource: %w", err)
+	}
+
 	folderParentID, name, username, uri, password, description, err := helper.GetResource(
 		ctx,
 		client,
@@ -71,7 +92,10 @@ func ResourceGet(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("Getting Resource: %w", err)
 	}
+	return printResource(folderParentID, name, username, uri, password, description, jsonOutput)
+}
 
+func printResource(folderParentID, name, username, uri, password, description string, jsonOutput bool) error {
 	if jsonOutput {
 		jsonResource, err := json.MarshalIndent(ResourceJsonOutput{
 			FolderParentID: &folderParentID,
@@ -97,7 +121,7 @@ func ResourceGet(cmd *cobra.Command, args []string) error {
 }
 
 func ResourcePermission(cmd *cobra.Command, args []string) error {
-	resource, err := cmd.Flags().GetString("id")
+	resource, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -112,6 +136,10 @@ func ResourcePermission(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	filter, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		return err
+	}
 
 	ctx, cancel := util.GetContext()
 	defer cancel()
@@ -123,63 +151,15 @@ func ResourcePermission(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
-	permissions, err := client.GetResourcePermissions(ctx, resource)
+	resource, err = ResolveResourceID(ctx, client, resource)
 	if err != nil {
-		return fmt.Errorf("Listing Permission: %w", err)
+		return fmt.Errorf("Resolving Resource: %w", err)
 	}
 
-	if jsonOutput {
-		outputPermissions := []util.PermissionJsonOutput{}
-		for i := range permissions {
-			outputPermissions = append(outputPermissions, util.PermissionJsonOutput{
-				ID:                &permissions[i].ID,
-				Aco:               &permissions[i].ACO,
-				AcoForeignKey:     &permissions[i].ACOForeignKey,
-				Aro:               &permissions[i].ARO,
-				AroForeignKey:     &permissions[i].AROForeignKey,
-				Type:              &permissions[i].Type,
-				CreatedTimestamp:  &permissions[i].Created.Time,
-				ModifiedTimestamp: &permissions[i].Modified.Time,
-			})
-		}
-		jsonPermissions, err := json.MarshalIndent(outputPermissions, "", "  ")
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(jsonPermissions))
-	} else {
-		data := pterm.TableData{columns}
-
-		for _, permission := range permissions {
-			entry := make([]string, len(columns))
-			for i := range columns {
-				switch strings.ToLower(columns[i]) {
-				case "id":
-					entry[i] = permission.ID
-				case "aco":
-					entry[i] = permission.ACO
-				case "acoforeignkey":
-					entry[i] = permission.ACOForeignKey
-				case "aro":
-					entry[i] = permission.ARO
-				case "aroforeignkey":
-					entry[i] = permission.AROForeignKey
-				case "type":
-					entry[i] = strconv.Itoa(permission.Type)
-				case "createdtimestamp":
-					entry[i] = permission.Created.Format(time.RFC3339)
-				case "modifiedtimestamp":
-					entry[i] = permission.Modified.Format(time.RFC3339)
-				default:
-					cmd.SilenceUsage = false
-					return fmt.Errorf("Unknown Column: %v", columns[i])
-				}
-			}
-			data = append(data, entry)
-		}
-
-		pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	permissions, err := client.GetResourcePermissions(ctx, resource)
+	if err != nil {
+		return fmt.Errorf("Listing Permission: %w", err)
 	}
 
-	return nil
+	return util.PrintPermissions(ctx, client, permissions, columns, jsonOutput, filter)
 }
@@ -0,0 +1,427 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+const localHistoryName = "history-resources"
+
+// maxLocalHistory limits the Number of locally tracked Changes, the oldest are dropped first
+const maxLocalHistory = 5000
+
+// ResourceHistoryCmd Shows the Change History of a Passbolt Resource
+var ResourceHistoryCmd = &cobra.Command{
+	Use:   "resource [id]",
+	Short: "Shows the Change History of a Passbolt Resource",
+	Long: `Shows who changed a Passbolt Resource and when, using the Action Logs of the Server (Passbolt v3 or newer).
+If the Server does not provide Action Logs (or with --local) the Changes tracked locally by the Metadata Cache (--cache) are shown instead,
+these only contain Changes noticed while refreshing the Cache.
+With --diff the current Resource is compared against the Metadata Cache or the Snapshot (--against snapshot), which also detects Password Changes.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeResourceArg,
+	RunE:              ResourceHistory,
+}
+
+func init() {
+	ResourceHistoryCmd.Flags().String("id", "", "id, name or path of Resource")
+	ResourceHistoryCmd.Flags().Int("limit", 50, "Maximum Number of History Entries to show")
+	ResourceHistoryCmd.Flags().Bool("local", false, "Only show Changes tracked locally by the Metadata Cache")
+	ResourceHistoryCmd.Flags().Bool("diff", false, "Compare the current Resource against the local Copy instead of showing the History")
+	ResourceHistoryCmd.Flags().String("against", "cache", "Local Copy to compare against with --diff: cache or snapshot")
+	ResourceHistoryCmd.Flags().BoolP("json", "j", false, "Output JSON")
+	ResourceHistoryCmd.RegisterFlagCompletionFunc("id", CompleteResourceIDs)
+}
+
+// HistoryEntry is a single Change of a Resource
+type HistoryEntry struct {
+	Time    time.Time `json:"time"`
+	User    string    `json:"user"`
+	Action  string    `json:"action"`
+	Details string    `json:"details"`
+	// Source is "server" for Action Logs or "local" for Changes tracked by the Metadata Cache
+	Source string `json:"source"`
+}
+
+// DiffEntry is a Field which differs between the local Copy and the current Resource
+type DiffEntry struct {
+	Field   string `json:"field"`
+	Local   string `json:"local"`
+	Current string `json:"current"`
+}
+
+// localChange is a Change noticed while refreshing the Metadata Cache
+type localChange struct {
+	Time       time.Time
+	ResourceID string
+	Action     string
+	ModifiedBy string
+	Fields     []string
+}
+
+func ResourceHistory(cmd *cobra.Command, args []string) error {
+	id, err := util.GetIDArg(cmd, args)
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return err
+	}
+	local, err := cmd.Flags().GetBool("local")
+	if err != nil {
+		return err
+	}
+	diff, err := cmd.Flags().GetBool("diff")
+	if err != nil {
+		return err
+	}
+	against, err := cmd.Flags().GetString("against")
+	if err != nil {
+		return err
+	}
+	if against != "cache" && against != "snapshot" {
+		return fmt.Errorf("Unknown --against %q, use cache or snapshot", against)
+	}
+	jsonOutput, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	id, err = ResolveResourceID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Resource: %w", err)
+	}
+
+	if diff {
+		entries, err := diffResource(ctx, client, id, against)
+		if err != nil {
+			return err
+		}
+		return printDiff(entries, jsonOutput)
+	}
+
+	var entries []HistoryEntry
+	if !local {
+		entries, err = getServerHistory(ctx, client, id, limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Action Logs are not available (%v), showing locally tracked Changes\n", err)
+			local = true
+		}
+	}
+	if local {
+		entries, err = getLocalHistory(ctx, client, id)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 && !cache.Enabled() {
+			fmt.Fprintln(os.Stderr, "No Changes tracked locally, use --cache to track Changes while refreshing the Metadata Cache")
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.After(entries[j].Time) })
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return printHistory(entries, jsonOutput)
+}
+
+// getServerHistory returns the Action Logs of a Resource
+func getServerHistory(ctx context.Context, client *api.Client, id string, limit int) ([]HistoryEntry, error) {
+	logs, err := util.GetActionLogs(ctx, client, "/actionlog/resource/"+id+".json", util.GetActionLogsOptions{
+		Limit: limit,
+		Page:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]HistoryEntry, 0, len(logs))
+	for _, l := range logs {
+		entry := HistoryEntry{
+			Action:  l.Type,
+			Details: util.ActionLogDetails(l.Data),
+			Source:  "server",
+		}
+		if l.Created != nil {
+			entry.Time = l.Created.Time
+		}
+		if l.Creator != nil {
+			entry.User = l.Creator.Username
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// getLocalHistory returns the Changes of a Resource tracked by the Metadata Cache
+func getLocalHistory(ctx context.Context, client *api.Client, id string) ([]HistoryEntry, error) {
+	var changes []localChange
+	_, err := cache.LoadData(client, localHistoryName, &changes)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("Loading local History: %w", err)
+	}
+
+	usernames := map[string]string{}
+	users, err := client.GetUsers(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Users: %w", err)
+	}
+	for _, u := range users {
+		usernames[u.ID] = u.Username
+	}
+
+	entries := []HistoryEntry{}
+	for _, c := range changes {
+		if c.ResourceID != id {
+			continue
+		}
+		details := "Changed: Secret or other Fields"
+		if len(c.Fields) != 0 {
+			details = fmt.Sprintf("Changed: %v", c.Fields)
+		}
+		if c.Action != "updated" {
+			details = ""
+		}
+		user := usernames[c.ModifiedBy]
+		if user == "" {
+			user = c.ModifiedBy
+		}
+		entries = append(entries, HistoryEntry{
+			Time:    c.Time,
+			User:    user,
+			Action:  c.Action,
+			Details: details,
+			Source:  "local",
+		})
+	}
+	return entries, nil
+}
+
+// recordLocalHistory stores the Changes between the old and new Content of the Metadata Cache.
+// A failing History should never fail the actual Command, so Errors are only printed.
+func recordLocalHistory(client *api.Client, cached, current []DecryptedResource) {
+	old := make(map[string]DecryptedResource, len(cached))
+	for _, d := range cached {
+		old[d.Resource.ID] = d
+	}
+
+	changes := []localChange{}
+	for _, d := range current {
+		change := localChange{
+			ResourceID: d.Resource.ID,
+			ModifiedBy: d.Resource.ModifiedBy,
+		}
+		if d.Resource.Modified != nil {
+			change.Time = d.Resource.Modified.Time
+		}
+
+		o, ok := old[d.Resource.ID]
+		delete(old, d.Resource.ID)
+		switch {
+		case !ok:
+			change.Action = "created"
+		case !sameTime(o.Resource.Modified, d.Resource.Modified):
+			change.Action = "updated"
+			change.Fields = changedFields(o, d)
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	for id := range old {
+		changes = append(changes, localChange{Time: time.Now(), ResourceID: id, Action: "deleted"})
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	var history []localChange
+	_, err := cache.LoadData(client, localHistoryName, &history)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load local history: %v\n", err)
+	}
+	history = append(history, changes...)
+	if len(history) > maxLocalHistory {
+		history = history[len(history)-maxLocalHistory:]
+	}
+
+	err = cache.SaveData(client, localHistoryName, history)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update local history: %v\n", err)
+	}
+}
+
+// changedFields returns the Names of the Metadata Fields which differ between a and b
+func changedFields(a, b DecryptedResource) []string {
+	fields := []string{}
+	if a.Name != b.Name {
+		fields = append(fields, "Name")
+	}
+	if a.Username != b.Username {
+		fields = append(fields, "Username")
+	}
+	if a.URI != b.URI {
+		fields = append(fields, "URI")
+	}
+	if a.Description != b.Description {
+		fields = append(fields, "Description")
+	}
+	if a.Resource.FolderParentID != b.Resource.FolderParentID {
+		fields = append(fields, "FolderParentID")
+	}
+	if !sameTime(a.Resource.Expired, b.Resource.Expired) {
+		fields = append(fields, "Expired")
+	}
+	return fields
+}
+
+// diffResource compares the current Resource against the Metadata Cache or Snapshot
+func diffResource(ctx context.Context, client *api.Client, id, against string) ([]DiffEntry, error) {
+	var localCopy DecryptedResource
+	var hasPassword bool
+	switch against {
+	case "snapshot":
+		var snapshot Snapshot
+		_, err := cache.LoadData(client, snapshotName, &snapshot)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("No Snapshot found, run \"passbolt snapshot sync\" first")
+			}
+			return nil, fmt.Errorf("Loading Snapshot: %w", err)
+		}
+		i := slices.IndexFunc(snapshot.Resources, func(r SnapshotResource) bool { return r.Resource.ID == id })
+		if i == -1 {
+			return nil, fmt.Errorf("Resource is not in the Snapshot")
+		}
+		r := snapshot.Resources[i]
+		localCopy = DecryptedResource{Resource: r.Resource, Name: r.Name, Username: r.Username, URI: r.URI, Password: r.Password, Description: r.Description}
+		hasPassword = true
+	default:
+		cached, _, err := cache.LoadMetadata[DecryptedResource](client, CacheKind)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("No Metadata Cache found, run a Command with --cache first")
+			}
+			return nil, fmt.Errorf("Loading Metadata Cache: %w", err)
+		}
+		i := slices.IndexFunc(cached, func(d DecryptedResource) bool { return d.Resource.ID == id })
+		if i == -1 {
+			return nil, fmt.Errorf("Resource is not in the Metadata Cache")
+		}
+		localCopy = cached[i]
+	}
+
+	resource, err := client.GetResource(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Getting Resource: %w", err)
+	}
+	folderParentID, name, username, uri, password, description, err := helper.GetResource(ctx, client, id)
+	if err != nil {
+		return nil, fmt.Errorf("Getting Resource: %w", err)
+	}
+
+	folders, err := client.GetFolders(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Folders: %w", err)
+	}
+	paths := folder.GetFolderPaths(folders)
+
+	entries := []DiffEntry{}
+	add := func(field, local, current string) {
+		if local != current {
+			entries = append(entries, DiffEntry{Field: field, Local: local, Current: current})
+		}
+	}
+	add("Name", localCopy.Name, name)
+	add("Username", localCopy.Username, username)
+	add("URI", localCopy.URI, uri)
+	add("Description", localCopy.Description, description)
+	add("Folder", paths[localCopy.Resource.FolderParentID], paths[folderParentID])
+	add("Expired", formatTime(expiredTime(localCopy.Resource)), formatTime(expiredTime(*resource)))
+	if hasPassword && localCopy.Password != password {
+		// Passwords are never printed, only that they changed
+		entries = append(entries, DiffEntry{Field: "Password", Local: "(hidden)", Current: "(changed)"})
+	}
+	return entries, nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func printHistory(entries []HistoryEntry, jsonOutput bool) error {
+	if jsonOutput {
+		jsonEntries, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Marshalling Json: %w", err)
+		}
+		fmt.Println(string(jsonEntries))
+		return nil
+	}
+
+	data := pterm.TableData{{"Time", "User", "Action", "Details", "Source"}}
+	for _, e := range entries {
+		data = append(data, []string{
+			formatTime(e.Time),
+			shellescape.StripUnsafe(e.User),
+			shellescape.StripUnsafe(e.Action),
+			shellescape.StripUnsafe(e.Details),
+			e.Source,
+		})
+	}
+	return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
+
+func printDiff(entries []DiffEntry, jsonOutput bool) error {
+	if jsonOutput {
+		jsonEntries, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Marshalling Json: %w", err)
+		}
+		fmt.Println(string(jsonEntries))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No Differences")
+		return nil
+	}
+	data := pterm.TableData{{"Field", "Local", "Current"}}
+	for _, e := range entries {
+		data = append(data, []string{
+			e.Field,
+			shellescape.StripUnsafe(e.Local),
+			shellescape.StripUnsafe(e.Current),
+		})
+	}
+	return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
@@ -12,4 +12,5 @@ type ResourceJsonOutput struct {
 	Description       *string    `json:"description,omitempty"`
 	CreatedTimestamp  *time.Time `json:"created_timestamp,omitempty"`
 	ModifiedTimestamp *time.Time `json:"modified_timestamp,omitempty"`
+	Expired           *time.Time `json:"expired,omitempty"`
 }
@@ -6,11 +6,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/group"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
@@ -21,14 +25,14 @@ import (
 
 // DecryptedResource holds the result of decrypting a single resource.
 type DecryptedResource struct {
-	Index       int
+	Index       int `json:"-"`
 	Resource    api.Resource
 	Name        string
 	Username    string
 	URI         string
-	Password    string
+	Password    string `json:"-"`
 	Description string
-	Err         error
+	Err         error `json:"-"`
 }
 
 // DecryptResourcesParallel decrypts resource metadata (and optionally secrets) in parallel.
@@ -52,9 +56,12 @@ func init() {
 	flags := ResourceListCmd.Flags()
 	flags.Bool("favorite", false, "Resources that are marked as favorite")
 	flags.Bool("own", false, "Resources that are owned by me")
-	flags.StringP("group", "g", "", "Resources that are shared with group")
-	flags.StringArrayP("folder", "f", []string{}, "Resources that are in folder")
-	flags.StringArrayP("column", "c", defaultTableColumns, "Columns to return (default list only for table format; JSON format includes all fields by default).\nPossible Columns: ID, FolderParentID, Name, Username, URI, Password, Description, CreatedTimestamp, ModifiedTimestamp")
+	flags.StringP("group", "g", "", "Resources that are shared with group, as id or name")
+	flags.StringArrayP("folder", "f", []string{}, "Resources that are in folder, as id or path (e.g. Infra/AWS)")
+	ResourceListCmd.RegisterFlagCompletionFunc("group", group.CompleteGroupIDs)
+	ResourceListCmd.RegisterFlagCompletionFunc("folder", folder.CompleteFolderIDs)
+	flags.StringArrayP("column", "c", defaultTableColumns, "Columns to return (default list only for table format; JSON format includes all fields by default).\nPossible Columns: ID, FolderParentID, Name, Username, URI, Password, Description, CreatedTimestamp, ModifiedTimestamp, Expired")
+	flags.String("expiringWithin", "", "Only Resources which expire within this Duration (e.g. 14d) or are already expired")
 }
 
 type resourceListConfig struct {
@@ -66,6 +73,10 @@ type resourceListConfig struct {
 	columnsChanged bool
 	jsonOutput     bool
 	celFilter      string
+	expiringWithin time.Duration
+	sortKeys       []util.SortKey
+	limit          int
+	offset         int
 }
 
 func ResourceList(cmd *cobra.Command, args []string) error {
@@ -93,31 +104,85 @@ func ResourceList(cmd *cobra.Command, args []string) error {
 		needSecrets = refsSecrets
 	}
 
+	// Check if sorting references Password or Description
+	if !needSecrets {
+		refsSecrets, err := util.SortKeysReferenceFields(config.sortKeys, []string{"Password", "Description"}, CelEnvOptions...)
+		if err != nil {
+			return fmt.Errorf("Parsing sort: %w", err)
+		}
+		needSecrets = refsSecrets
+	}
+
 	ctx, cancel := util.GetContext()
 	defer cancel()
 
-	client, err := util.GetClient(ctx)
-	if err != nil {
-		return err
-	}
-	defer util.SaveSessionKeysAndLogout(ctx, client)
-	cmd.SilenceUsage = true
+	var decrypted []DecryptedResource
+	if viper.GetBool("offline") {
+		if config.favorite || config.own || config.group != "" {
+			return fmt.Errorf("The favorite, own and group Filters are not available in Offline Mode")
+		}
 
-	resources, err := client.GetResources(ctx, &api.GetResourcesOptions{
-		FilterIsFavorite:        config.favorite,
-		FilterIsOwnedByMe:       config.own,
-		FilterIsSharedWithGroup: config.group,
-		FilterHasParent:         config.folderParents,
-		ContainSecret:           needSecrets,
-	})
-	if err != nil {
-		return fmt.Errorf("Listing Resource: %w", err)
+		snapshot, _, err := LoadSnapshot()
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+
+		decrypted, err = filterResourcesByFolders(snapshot.Folders, snapshot.Decrypted(), config.folderParents)
+		if err != nil {
+			return err
+		}
+	} else if cache.Enabled() && !needSecrets && !config.favorite && !config.own && config.group == "" {
+		// Secrets and Server side Filters can't be served from the Cache
+		client, err := cache.NewClient()
+		if err != nil {
+			return err
+		}
+		defer client.Logout(ctx)
+		cmd.SilenceUsage = true
+
+		decrypted, err = listCachedResources(ctx, client, config)
+		if err != nil {
+			return err
+		}
+	} else {
+		client, err := util.GetClient(ctx)
+		if err != nil {
+			return err
+		}
+		defer util.SaveSessionKeysAndLogout(ctx, client)
+		cmd.SilenceUsage = true
+
+		config.group, err = util.ResolveGroupID(ctx, client, config.group)
+		if err != nil {
+			return fmt.Errorf("Resolving Group: %w", err)
+		}
+
+		config.folderParents, err = folder.ResolveFolderIDs(ctx, client, config.folderParents)
+		if err != nil {
+			return fmt.Errorf("Resolving Folders: %w", err)
+		}
+
+		resources, err := client.GetResources(ctx, &api.GetResourcesOptions{
+			FilterIsFavorite:        config.favorite,
+			FilterIsOwnedByMe:       config.own,
+			FilterIsSharedWithGroup: config.group,
+			FilterHasParent:         config.folderParents,
+			ContainSecret:           needSecrets,
+		})
+		if err != nil {
+			return fmt.Errorf("Listing Resource: %w", err)
+		}
+
+		// Decrypt all resources in parallel
+		decrypted, err = decryptResourcesParallel(ctx, client, resources, needSecrets)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Decrypt all resources in parallel
-	decrypted, err := decryptResourcesParallel(ctx, client, resources, needSecrets)
-	if err != nil {
-		return err
+	if config.expiringWithin != 0 {
+		decrypted = filterExpiringResources(decrypted, config.expiringWithin)
 	}
 
 	// Apply CEL filter on already-decrypted data
@@ -128,6 +193,13 @@ func ResourceList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Sort and paginate after filtering so decrypted v5 metadata can be used
+	err = util.SortByKeys(ctx, decrypted, config.sortKeys, resourceCelVars, CelEnvOptions...)
+	if err != nil {
+		return err
+	}
+	decrypted = util.Paginate(decrypted, config.offset, config.limit)
+
 	if config.jsonOutput {
 		return printJsonResources(decrypted, config.columnsChanged, config.columns)
 	}
@@ -135,6 +207,47 @@ func ResourceList(cmd *cobra.Command, args []string) error {
 	return printTableResources(decrypted, config.columns)
 }
 
+// listCachedResources applies the Folder Filter locally on the cached Resources
+func listCachedResources(ctx context.Context, client *cache.Client, config *resourceListConfig) ([]DecryptedResource, error) {
+	decrypted, err := cache.List(ctx, client, CacheKind, FetchResources)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.folderParents) == 0 {
+		return decrypted, nil
+	}
+
+	folders, err := cache.List(ctx, client, folder.CacheKind, folder.FetchFolders)
+	if err != nil {
+		return nil, err
+	}
+	return filterResourcesByFolders(folders, decrypted, config.folderParents)
+}
+
+// filterResourcesByFolders does the same as the Servers Folder Filter, for Resources not fetched from the Server
+func filterResourcesByFolders(folders []api.Folder, decrypted []DecryptedResource, folderParents []string) ([]DecryptedResource, error) {
+	if len(folderParents) == 0 {
+		return decrypted, nil
+	}
+
+	ids := make([]string, len(folderParents))
+	for i, parent := range folderParents {
+		id, err := folder.ResolveFolderIDFromList(folders, parent)
+		if err != nil {
+			return nil, fmt.Errorf("Resolving Folders: %w", err)
+		}
+		ids[i] = id
+	}
+
+	filtered := []DecryptedResource{}
+	for _, d := range decrypted {
+		if slices.Contains(ids, d.Resource.FolderParentID) {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered, nil
+}
+
 func decryptResourcesParallel(ctx context.Context, client *api.Client, resources []api.Resource, needSecrets bool) ([]DecryptedResource, error) {
 	// Use parallel decryption with worker pool
 	numWorkers := int(viper.GetUint("workers"))
@@ -290,6 +403,10 @@ func printJsonResources(
 		uri := d.URI
 		pass := d.Password
 		desc := d.Description
+		var expired *time.Time
+		if e := expiredTime(d.Resource); !e.IsZero() {
+			expired = &e
+		}
 		outputResources[i] = ResourceJsonOutput{
 			ID:                &d.Resource.ID,
 			FolderParentID:    &d.Resource.FolderParentID,
@@ -300,6 +417,7 @@ func printJsonResources(
 			Description:       &desc,
 			CreatedTimestamp:  &d.Resource.Created.Time,
 			ModifiedTimestamp: &d.Resource.Modified.Time,
+			Expired:           expired,
 		}
 	}
 
@@ -364,6 +482,10 @@ func printTableResources(
 				entry[i] = d.Resource.Created.Format(time.RFC3339)
 			case "modifiedtimestamp":
 				entry[i] = d.Resource.Modified.Format(time.RFC3339)
+			case "expired":
+				if e := expiredTime(d.Resource); !e.IsZero() {
+					entry[i] = e.Format(time.RFC3339)
+				}
 			default:
 				return fmt.Errorf("Unknown Column: %v", columns[i])
 			}
@@ -407,6 +529,21 @@ func parseResourceListFlags(cmd *cobra.Command) (*resourceListConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	expiringWithinInput, err := cmd.Flags().GetString("expiringWithin")
+	if err != nil {
+		return nil, err
+	}
+	var expiringWithin time.Duration
+	if expiringWithinInput != "" {
+		expiringWithin, err = util.ParseDuration(expiringWithinInput)
+		if err != nil {
+			return nil, fmt.Errorf("Parsing expiringWithin: %w", err)
+		}
+	}
+	sortKeys, limit, offset, err := util.GetSortAndPagination(cmd, CelEnvOptions...)
+	if err != nil {
+		return nil, err
+	}
 
 	return &resourceListConfig{
 		favorite:       favorite,
@@ -417,5 +554,9 @@ func parseResourceListFlags(cmd *cobra.Command) (*resourceListConfig, error) {
 		columnsChanged: cmd.Flags().Changed("column"),
 		jsonOutput:     jsonOutput,
 		celFilter:      celFilter,
+		expiringWithin: expiringWithin,
+		sortKeys:       sortKeys,
+		limit:          limit,
+		offset:         offset,
 	}, nil
 }
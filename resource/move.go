@@ -1,31 +1,39 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/passbolt/go-passbolt-cli/folder"
 	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
 	"github.com/spf13/cobra"
 )
 
 // ResourceMoveCmd Moves a Passbolt Resource
 var ResourceMoveCmd = &cobra.Command{
-	Use:   "resource",
-	Short: "Moves a Passbolt Resource into a Folder",
-	Long:  `Moves a Passbolt Resource into a Folder`,
-	RunE:  ResourceMove,
+	Use:               "resource [id]",
+	Short:             "Moves a Passbolt Resource into a Folder",
+	Long:              `Moves a Passbolt Resource into a Folder`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeResourceArg,
+	RunE:              ResourceMove,
 }
 
 func init() {
-	ResourceMoveCmd.Flags().String("id", "", "id of Resource to Move")
-	ResourceMoveCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to Move the Resource")
+	ResourceMoveCmd.Flags().String("id", "", "id, name or path of Resource to Move")
+	ResourceMoveCmd.Flags().StringP("folderParentID", "f", "", "Folder in which to Move the Resource, as id or path (e.g. Infra/AWS)")
+	ResourceMoveCmd.Flags().Bool("inheritPermissions", false, "Give the Resource the Permissions of the Folder it is moved into")
+	ResourceMoveCmd.RegisterFlagCompletionFunc("folderParentID", folder.CompleteFolderIDs)
 
-	ResourceMoveCmd.MarkFlagRequired("id")
+	ResourceMoveCmd.RegisterFlagCompletionFunc("id", CompleteResourceIDs)
 	ResourceMoveCmd.MarkFlagRequired("folderParentID")
+	addBulkFlags(ResourceMoveCmd)
 }
 
 func ResourceMove(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, filter, yes, err := getIDOrFilter(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -33,6 +41,10 @@ func ResourceMove(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	inherit, err := cmd.Flags().GetBool("inheritPermissions")
+	if err != nil {
+		return err
+	}
 
 	ctx, cancel := util.GetContext()
 	defer cancel()
@@ -44,14 +56,60 @@ func ResourceMove(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
-	err = helper.MoveResource(
-		ctx,
-		client,
-		id,
-		folderParentID,
-	)
+	folderParentID, err = folder.ResolveFolderID(ctx, client, folderParentID)
+	if err != nil {
+		return fmt.Errorf("Resolving Folder: %w", err)
+	}
+
+	var spec *util.ShareSpec
+	if inherit {
+		if folderParentID == "" {
+			return fmt.Errorf("The Root has no Permissions to inherit")
+		}
+		parent, err := client.GetFolder(ctx, folderParentID, &api.GetFolderOptions{
+			ContainPermissions: true,
+		})
+		if err != nil {
+			return fmt.Errorf("Getting Folder Permissions: %w", err)
+		}
+		spec = util.ShareSpecFromPermissions(parent.Permissions, false)
+	}
+
+	if filter != "" {
+		return bulk(ctx, client, "Move", filter, yes, func(ctx context.Context, d DecryptedResource) error {
+			err := helper.MoveResource(ctx, client, d.Resource.ID, folderParentID)
+			if err != nil || spec == nil {
+				return err
+			}
+			return shareResource(ctx, client, d.Resource.ID, spec, nil)
+		})
+	}
+
+	id, err = ResolveResourceID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Resource: %w", err)
+	}
+
+	move := func() error {
+		err := helper.MoveResource(
+			ctx,
+			client,
+			id,
+			folderParentID,
+		)
+		if err != nil {
+			return fmt.Errorf("Moving Resource: %w", err)
+		}
+		return nil
+	}
+	if spec == nil {
+		return move()
+	}
+
+	current, err := client.GetResourcePermissions(ctx, id)
 	if err != nil {
-		return fmt.Errorf("Moving Resource: %w", err)
+		return fmt.Errorf("Getting Permissions: %w", err)
 	}
-	return nil
+	target := &util.ShareTarget{Kind: "Resource", ID: id, Name: id, Current: current}
+	return util.InheritPermissions(ctx, client, spec, []*util.ShareTarget{target}, false, yes, move)
 }
@@ -0,0 +1,45 @@
+package resource
+
+import (
+	"context"
+	"strings"
+
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// ResolveResourceID returns the ID of the Resource referenced by an ID, Name or Path like "Prod/DB/root".
+// Names of v5 Resources are decrypted from their Metadata for matching.
+func ResolveResourceID(ctx context.Context, client *api.Client, input string) (string, error) {
+	if input == "" || util.IsUUID(input) {
+		return input, nil
+	}
+
+	decrypted, folderPaths, err := getResourcesAndFolderPaths(ctx, client)
+	if err != nil {
+		return "", err
+	}
+	return resolveResourceIDFromList(folderPaths, decrypted, input)
+}
+
+func resolveResourceIDFromList(folderPaths map[string]string, decrypted []DecryptedResource, input string) (string, error) {
+	candidates := make([]util.Candidate, len(decrypted))
+	for i, d := range decrypted {
+		path := ResourcePath(folderPaths, d)
+		candidates[i] = util.Candidate{
+			ID:      d.Resource.ID,
+			Names:   []string{path, d.Name},
+			Display: path,
+		}
+	}
+	return util.ResolveCandidate("resource", strings.TrimPrefix(input, folder.PathSeparator), candidates)
+}
+
+// ResourcePath returns the Path of a Resource including the Path of its Folder
+func ResourcePath(folderPaths map[string]string, d DecryptedResource) string {
+	if parent, ok := folderPaths[d.Resource.FolderParentID]; ok {
+		return parent + folder.PathSeparator + d.Name
+	}
+	return d.Name
+}
@@ -0,0 +1,207 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ResourceRotateCmd Rotates the Password of a Passbolt Resource
+var ResourceRotateCmd = &cobra.Command{
+	Use:   "resource [id] [-- hook [args...]]",
+	Short: "Rotates the Password of a Passbolt Resource",
+	Long: `Generates a new Password for a Passbolt Resource and updates the Resource.
+A Hook Command can be given after "--" to change the Credential at the Target System.
+It gets the Secrets in the Environment Variables PASSBOLT_OLD_PASSWORD and PASSBOLT_NEW_PASSWORD,
+as well as PASSBOLT_RESOURCE_ID, PASSBOLT_RESOURCE_NAME, PASSBOLT_RESOURCE_USERNAME and PASSBOLT_RESOURCE_URI.
+If the Hook fails or runs longer than --hookTimeout, the Resource is rolled back to the old Password.
+The Rotation is recorded by setting the Expiry from --expiry or the default Expiry Period of the Server.
+Without either (or with --expiry none) a "Last Rotated:" Line with the Rotation Time is written to the Description instead.
+With --filter the Resources are rotated one after another, so Hooks never run at the same time, each with its own --timeout.
+
+For example:
+	passbolt rotate resource --id db-admin --expiry 90d -- ./change-db-password.sh`,
+	ValidArgsFunction: completeResourceArg,
+	RunE:              ResourceRotate,
+}
+
+func init() {
+	ResourceRotateCmd.Flags().String("id", "", "id, name or path of Resource to Rotate")
+	ResourceRotateCmd.Flags().Duration("hookTimeout", 5*time.Minute, "Timeout for the Hook Command, it is killed and the Resource rolled back afterwards")
+	ResourceRotateCmd.Flags().String("expiry", "", "Expiry to set after rotating as RFC3339 or duration (e.g. 90d), to record when the next Rotation is due. Defaults to the Expiry Period of the Server")
+	ResourceRotateCmd.RegisterFlagCompletionFunc("id", CompleteResourceIDs)
+	addBulkFlags(ResourceRotateCmd)
+	util.AddGeneratorFlags(ResourceRotateCmd)
+}
+
+func ResourceRotate(cmd *cobra.Command, args []string) error {
+	var hook []string
+	if dash := cmd.ArgsLenAtDash(); dash != -1 {
+		hook = args[dash:]
+		args = args[:dash]
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("Only one Resource ID can be given, use \"--\" before the Hook Command")
+	}
+
+	id, filter, yes, err := getIDOrFilter(cmd, args)
+	if err != nil {
+		return err
+	}
+	expiry, err := cmd.Flags().GetString("expiry")
+	if err != nil {
+		return err
+	}
+	hookTimeout, err := cmd.Flags().GetDuration("hookTimeout")
+	if err != nil {
+		return err
+	}
+	if expiry != "" && !strings.EqualFold(expiry, "none") {
+		// Fail before anything is rotated
+		_, err = ParseExpiry(expiry)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	policy, err := util.GetGeneratorPolicy(ctx, cmd, client)
+	if err != nil {
+		return err
+	}
+	if period := client.GetPasswordExpirySettings().DefaultExpiryPeriod; expiry == "" && period != 0 {
+		expiry = fmt.Sprintf("%dd", period)
+	}
+
+	rotate := func(ctx context.Context, id string) error {
+		return rotateResource(ctx, client, id, policy, hook, hookTimeout, expiry)
+	}
+
+	if filter != "" {
+		// Hooks change Credentials at Target Systems, running them one after another keeps their Output and Failures apart
+		return bulkWithWorkers(ctx, client, "Rotate", filter, yes, 1, func(_ context.Context, d DecryptedResource) error {
+			// Every Resource gets its own Timeout, otherwise earlier Hooks use up the Time of later Resources
+			ctx, cancel := util.GetContext()
+			defer cancel()
+			return rotate(ctx, d.Resource.ID)
+		})
+	}
+
+	id, err = ResolveResourceID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Resource: %w", err)
+	}
+	err = rotate(ctx, id)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Rotated Resource %v\n", id)
+	return nil
+}
+
+// rotateResource sets a new generated Password, runs the Hook and rolls back if it fails.
+// The Rotation is recorded as Expiry, or in the Description if expiry is empty or "none".
+func rotateResource(ctx context.Context, client *api.Client, id string, policy util.GeneratorPolicy, hook []string, hookTimeout time.Duration, expiry string) error {
+	_, name, username, uri, oldPassword, description, err := helper.GetResource(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Getting Resource: %w", err)
+	}
+
+	newPassword, err := util.GeneratePassword(policy)
+	if err != nil {
+		return fmt.Errorf("Generating Password: %w", err)
+	}
+
+	// Passbolt is updated first so the new Password can never get lost
+	err = helper.UpdateResource(ctx, client, id, "", "", "", newPassword, "")
+	if err != nil {
+		return fmt.Errorf("Updating Resource: %w", err)
+	}
+
+	if len(hook) != 0 {
+		// The Hook does not share the Timeout of the Passbolt Requests, as it may take a while
+		hookCtx, hookCancel := context.WithTimeout(context.WithoutCancel(ctx), hookTimeout)
+		defer hookCancel()
+		hookCmd := exec.CommandContext(hookCtx, hook[0], hook[1:]...)
+		hookCmd.Stdout = os.Stdout
+		hookCmd.Stderr = os.Stderr
+		hookCmd.Env = append(os.Environ(),
+			"PASSBOLT_RESOURCE_ID="+id,
+			"PASSBOLT_RESOURCE_NAME="+name,
+			"PASSBOLT_RESOURCE_USERNAME="+username,
+			"PASSBOLT_RESOURCE_URI="+uri,
+			"PASSBOLT_OLD_PASSWORD="+oldPassword,
+			"PASSBOLT_NEW_PASSWORD="+newPassword,
+		)
+
+		hookErr := hookCmd.Run()
+		if errors.Is(hookCtx.Err(), context.DeadlineExceeded) {
+			hookErr = fmt.Errorf("Timed out after %v: %w", hookTimeout, hookErr)
+		}
+
+		// ctx may have expired while the Hook was running
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), viper.GetDuration("timeout"))
+		defer cancel()
+
+		if hookErr != nil {
+			err = helper.UpdateResource(ctx, client, id, "", "", "", oldPassword, "")
+			if err != nil {
+				// Passbolt now only has the new Password, which the Target System may not have accepted
+				fmt.Fprintf(os.Stderr, "Rolling back Resource %v failed, its old Password was: %v\n", id, oldPassword)
+				return fmt.Errorf("Running Hook: %w, Rolling back Resource also failed (the old Password was written to stderr): %w", hookErr, err)
+			}
+			return fmt.Errorf("Running Hook: %w, Resource was rolled back", hookErr)
+		}
+	}
+
+	// Clears the Expiry for "none", does nothing without an Expiry
+	err = SetResourceExpiry(ctx, client, id, expiry)
+	if err != nil {
+		return fmt.Errorf("Rotated, but %w", err)
+	}
+	if expiry == "" || strings.EqualFold(expiry, "none") {
+		err = helper.UpdateResource(ctx, client, id, "", "", "", "", recordRotation(description, time.Now()))
+		if err != nil {
+			return fmt.Errorf("Rotated, but Recording the Rotation Time failed: %w", err)
+		}
+	}
+	return nil
+}
+
+const lastRotatedPrefix = "Last Rotated: "
+
+// recordRotation replaces the "Last Rotated:" Line of the Description or appends one
+func recordRotation(description string, rotated time.Time) string {
+	line := lastRotatedPrefix + rotated.UTC().Format(time.RFC3339)
+	lines := strings.Split(description, "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(l, lastRotatedPrefix) {
+			lines[i] = line
+			return strings.Join(lines, "\n")
+		}
+	}
+	if description == "" {
+		return line
+	}
+	return description + "\n" + line
+}
@@ -1,45 +1,47 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/passbolt/go-passbolt-cli/group"
+	"github.com/passbolt/go-passbolt-cli/user"
 	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
 	"github.com/spf13/cobra"
 )
 
 // ResourceShareCmd Shares a Passbolt Resource
 var ResourceShareCmd = &cobra.Command{
-	Use:   "resource",
+	Use:   "resource [id]",
 	Short: "Shares a Passbolt Resource",
-	Long:  `Shares a Passbolt Resource`,
-	RunE:  ResourceShare,
+	Long: `Shares a Passbolt Resource.
+Users and Groups can be given with --user and --group and the Permission --type,
+or with their Permission Level using --read, --update and --owner (e.g. --owner jane@example.com --read group:Developers).
+--revoke removes the Permissions of --user and --group, --replace revokes all Permissions not given.
+The Permissions before and after the Change are shown before applying, --replace additionally asks for Confirmation.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeResourceArg,
+	RunE:              ResourceShare,
 }
 
 func init() {
-	ResourceShareCmd.Flags().String("id", "", "id of Resource to Share")
-	ResourceShareCmd.Flags().IntP("type", "t", 1, "Permission Type (1 Read Only, 7 Can Update, 15 Owner)")
-	ResourceShareCmd.Flags().StringArrayP("user", "u", []string{}, "User id's to share with")
-	ResourceShareCmd.Flags().StringArrayP("group", "g", []string{}, "Group id's to share with")
+	ResourceShareCmd.Flags().String("id", "", "id, name or path of Resource to Share")
+	util.AddShareFlags(ResourceShareCmd)
+	ResourceShareCmd.RegisterFlagCompletionFunc("user", user.CompleteUserIDs)
+	ResourceShareCmd.RegisterFlagCompletionFunc("group", group.CompleteGroupIDs)
 
-	ResourceShareCmd.MarkFlagRequired("id")
-	ResourceShareCmd.MarkFlagRequired("type")
+	ResourceShareCmd.RegisterFlagCompletionFunc("id", CompleteResourceIDs)
+	addBulkFlags(ResourceShareCmd)
 }
 
 func ResourceShare(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, filter, yes, err := getIDOrFilter(cmd, args)
 	if err != nil {
 		return err
 	}
-	pType, err := cmd.Flags().GetInt("type")
-	if err != nil {
-		return err
-	}
-	users, err := cmd.Flags().GetStringArray("user")
-	if err != nil {
-		return err
-	}
-	groups, err := cmd.Flags().GetStringArray("group")
+	spec, err := util.GetShareSpec(cmd)
 	if err != nil {
 		return err
 	}
@@ -54,14 +56,53 @@ func ResourceShare(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
-	err = helper.ShareResourceWithUsersAndGroups(
-		ctx,
-		client,
-		id,
-		users,
-		groups,
-		pType,
-	)
+	err = spec.Resolve(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if filter != "" {
+		return bulk(ctx, client, "Share", filter, yes, func(ctx context.Context, d DecryptedResource) error {
+			return shareResource(ctx, client, d.Resource.ID, spec, nil)
+		})
+	}
+
+	id, err = ResolveResourceID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Resource: %w", err)
+	}
+
+	return shareResource(ctx, client, id, spec, func(current []api.Permission, changes []helper.ShareOperation) error {
+		err := util.PrintShareDiff(ctx, client, current, changes)
+		if err != nil {
+			return err
+		}
+		if spec.Replace && len(changes) != 0 {
+			return util.Confirm("Replace the Permissions of this Resource?", yes)
+		}
+		return nil
+	})
+}
+
+// shareResource applies the ShareSpec to a Resource, preview is called with the Changes before applying them if set
+func shareResource(ctx context.Context, client *api.Client, id string, spec *util.ShareSpec, preview func(current []api.Permission, changes []helper.ShareOperation) error) error {
+	current, err := client.GetResourcePermissions(ctx, id)
+	if err != nil {
+		return fmt.Errorf("Getting Permissions: %w", err)
+	}
+
+	changes := spec.Changes(current)
+	if preview != nil {
+		err = preview(current, changes)
+		if err != nil {
+			return err
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	err = helper.ShareResource(ctx, client, id, changes)
 	if err != nil {
 		return fmt.Errorf("Sharing Resource: %w", err)
 	}
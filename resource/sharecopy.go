@@ -0,0 +1,178 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/spf13/cobra"
+)
+
+// ShareCopyCmd Copies the Permissions of a Resource or Folder to others
+var ShareCopyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copies the Permissions of a Resource or Folder to others",
+	Long: `Copies the Users and Groups and their Permissions from one Resource (--from) or Folder (--fromFolder)
+to the Resources given by --to or --filter and the Folders given by --toFolder.
+By default the Targets get exactly the same Permissions, --additive only adds missing Permissions and never lowers or revokes any.
+The Changes for every Target are shown and need to be confirmed before applying.`,
+	Args: cobra.NoArgs,
+	RunE: ShareCopy,
+}
+
+func init() {
+	ShareCopyCmd.Flags().String("from", "", "id, name or path of the Resource to copy the Permissions from")
+	ShareCopyCmd.Flags().String("fromFolder", "", "id or path of the Folder to copy the Permissions from")
+	ShareCopyCmd.Flags().StringArray("to", []string{}, "id, name or path of Resources to copy the Permissions to")
+	ShareCopyCmd.Flags().StringArray("toFolder", []string{}, "id or path of Folders to copy the Permissions to")
+	ShareCopyCmd.Flags().String("filter", "", "CEL expression selecting Resources to copy the Permissions to, the same Variables as in \"list resource --filter\" can be used")
+	ShareCopyCmd.Flags().Bool("additive", false, "Only add missing Permissions, never lower or revoke existing ones")
+	ShareCopyCmd.Flags().BoolP("yes", "y", false, "Don't ask for Confirmation before applying")
+	ShareCopyCmd.RegisterFlagCompletionFunc("from", CompleteResourceIDs)
+	ShareCopyCmd.RegisterFlagCompletionFunc("to", CompleteResourceIDs)
+	ShareCopyCmd.RegisterFlagCompletionFunc("fromFolder", folder.CompleteFolderIDs)
+	ShareCopyCmd.RegisterFlagCompletionFunc("toFolder", folder.CompleteFolderIDs)
+}
+
+func ShareCopy(cmd *cobra.Command, args []string) error {
+	from, err := cmd.Flags().GetString("from")
+	if err != nil {
+		return err
+	}
+	fromFolder, err := cmd.Flags().GetString("fromFolder")
+	if err != nil {
+		return err
+	}
+	to, err := cmd.Flags().GetStringArray("to")
+	if err != nil {
+		return err
+	}
+	toFolders, err := cmd.Flags().GetStringArray("toFolder")
+	if err != nil {
+		return err
+	}
+	filter, err := cmd.Flags().GetString("filter")
+	if err != nil {
+		return err
+	}
+	additive, err := cmd.Flags().GetBool("additive")
+	if err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+	if (from == "") == (fromFolder == "") {
+		return fmt.Errorf("Either --from or --fromFolder is required")
+	}
+	if len(to) == 0 && len(toFolders) == 0 && filter == "" {
+		return fmt.Errorf("At least one of --to, --toFolder or --filter is required")
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	var source []api.Permission
+	if from != "" {
+		id, err := ResolveResourceID(ctx, client, from)
+		if err != nil {
+			return fmt.Errorf("Resolving Resource: %w", err)
+		}
+		source, err = client.GetResourcePermissions(ctx, id)
+		if err != nil {
+			return fmt.Errorf("Getting Permissions: %w", err)
+		}
+	} else {
+		_, source, err = getFolderPermissions(ctx, client, fromFolder)
+		if err != nil {
+			return err
+		}
+	}
+	spec := util.ShareSpecFromPermissions(source, additive)
+
+	targets, err := getShareTargets(ctx, client, to, toFolders, filter)
+	if err != nil {
+		return err
+	}
+
+	pending, err := util.PlanShareTargets(ctx, client, targets, spec)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		fmt.Println("No Changes, all Targets already have these Permissions.")
+		return nil
+	}
+
+	err = util.Confirm(fmt.Sprintf("Change the Permissions of %d Targets?", len(pending)), yes)
+	if err != nil {
+		return err
+	}
+	return util.ApplyShareTargets(ctx, client, pending)
+}
+
+// getShareTargets resolves all Targets and fetches their current Permissions
+func getShareTargets(ctx context.Context, client *api.Client, to, toFolders []string, filter string) ([]*util.ShareTarget, error) {
+	targets := []*util.ShareTarget{}
+	if filter != "" {
+		resources, err := selectResources(ctx, client, filter)
+		if err != nil {
+			return nil, err
+		}
+		if len(resources) == 0 {
+			return nil, fmt.Errorf("No such Resources found with filter %v!", filter)
+		}
+		for _, d := range resources {
+			targets = append(targets, &util.ShareTarget{Kind: "Resource", ID: d.Resource.ID, Name: d.Name})
+		}
+	}
+	for _, input := range to {
+		id, err := ResolveResourceID(ctx, client, input)
+		if err != nil {
+			return nil, fmt.Errorf("Resolving Resource: %w", err)
+		}
+		targets = append(targets, &util.ShareTarget{Kind: "Resource", ID: id, Name: input})
+	}
+
+	for _, t := range targets {
+		var err error
+		t.Current, err = client.GetResourcePermissions(ctx, t.ID)
+		if err != nil {
+			return nil, fmt.Errorf("Getting Permissions of %v: %w", t.Name, err)
+		}
+	}
+
+	for _, input := range toFolders {
+		id, current, err := getFolderPermissions(ctx, client, input)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, &util.ShareTarget{Kind: "Folder", ID: id, Name: input, Current: current})
+	}
+	return targets, nil
+}
+
+// getFolderPermissions returns the ID and Permissions of a Folder
+func getFolderPermissions(ctx context.Context, client *api.Client, input string) (string, []api.Permission, error) {
+	id, err := folder.ResolveFolderID(ctx, client, input)
+	if err != nil {
+		return "", nil, fmt.Errorf("Resolving Folder: %w", err)
+	}
+	f, err := client.GetFolder(ctx, id, &api.GetFolderOptions{
+		ContainPermissions: true,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("Getting Permissions: %w", err)
+	}
+	return id, f.Permissions, nil
+}
@@ -0,0 +1,178 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt-cli/folder"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/spf13/cobra"
+)
+
+const snapshotName = "snapshot"
+
+// Snapshot is a decrypted copy of Resources including their Secrets for Offline use, it is stored encrypted to the Users Key
+type Snapshot struct {
+	Folders   []api.Folder
+	Resources []SnapshotResource
+}
+
+// SnapshotResource is a single Resource of a Snapshot
+type SnapshotResource struct {
+	Resource    api.Resource
+	Name        string
+	Username    string
+	URI         string
+	Password    string
+	Description string
+}
+
+// SnapshotSyncCmd Stores a Snapshot for Offline use
+var SnapshotSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Stores an encrypted Snapshot of Resources for Offline use",
+	Long: `Stores an encrypted Snapshot of Resources including their Secrets for Offline use.
+The Snapshot can then be used with --offline by "get resource", "list resource", "exec" and "ui" while the Server is not reachable.`,
+	Args: cobra.NoArgs,
+	RunE: SnapshotSync,
+}
+
+func init() {
+	SnapshotSyncCmd.Flags().StringArrayP("folder", "f", []string{}, "Only include Resources in this Folder and its Subfolders, as id or path (e.g. Infra/AWS). Includes all Resources by default")
+	SnapshotSyncCmd.RegisterFlagCompletionFunc("folder", folder.CompleteFolderIDs)
+}
+
+func SnapshotSync(cmd *cobra.Command, args []string) error {
+	folderInputs, err := cmd.Flags().GetStringArray("folder")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	folders, err := client.GetFolders(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Listing Folders: %w", err)
+	}
+
+	opts := &api.GetResourcesOptions{
+		ContainSecret: true,
+	}
+	if len(folderInputs) != 0 {
+		selected := []string{}
+		for _, input := range folderInputs {
+			id, err := folder.ResolveFolderIDFromList(folders, input)
+			if err != nil {
+				return fmt.Errorf("Resolving Folders: %w", err)
+			}
+			selected = append(selected, folder.SubfolderIDs(folders, id)...)
+		}
+		opts.FilterHasParent = selected
+	}
+
+	resources, err := client.GetResources(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("Listing Resource: %w", err)
+	}
+
+	decrypted, err := decryptResourcesParallel(ctx, client, resources, true)
+	if err != nil {
+		return err
+	}
+
+	snapshot := Snapshot{
+		Folders:   folders,
+		Resources: make([]SnapshotResource, len(decrypted)),
+	}
+	for i, d := range decrypted {
+		snapshot.Resources[i] = SnapshotResource{
+			Resource:    stripResource(d.Resource),
+			Name:        d.Name,
+			Username:    d.Username,
+			URI:         d.URI,
+			Password:    d.Password,
+			Description: d.Description,
+		}
+	}
+
+	err = cache.SaveData(client, snapshotName, snapshot)
+	if err != nil {
+		return fmt.Errorf("Saving Snapshot: %w", err)
+	}
+	fmt.Printf("Saved Snapshot of %v Resources\n", len(snapshot.Resources))
+	return nil
+}
+
+// LoadSnapshot loads the Snapshot stored by "snapshot sync" and prints its age to stderr.
+// Only the Private Key is unlocked, the Server is not contacted.
+func LoadSnapshot() (*Snapshot, time.Time, error) {
+	client, err := util.NewClient(true)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var snapshot Snapshot
+	updated, err := cache.LoadData(client, snapshotName, &snapshot)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, time.Time{}, fmt.Errorf("No Snapshot found, run \"passbolt snapshot sync\" first")
+		}
+		return nil, time.Time{}, fmt.Errorf("Loading Snapshot: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Offline: using Snapshot from %v\n", SnapshotAge(updated))
+	return &snapshot, updated, nil
+}
+
+// SnapshotAge describes when a Snapshot was taken, e.g. "2024-01-02T15:04:05Z (3h0m0s ago)"
+func SnapshotAge(updated time.Time) string {
+	return fmt.Sprintf("%v (%v ago)", updated.Format(time.RFC3339), time.Since(updated).Round(time.Second))
+}
+
+// Decrypted returns the Resources of the Snapshot in the same form as decrypted ones from the Server
+func (s *Snapshot) Decrypted() []DecryptedResource {
+	decrypted := make([]DecryptedResource, len(s.Resources))
+	for i, r := range s.Resources {
+		decrypted[i] = DecryptedResource{
+			Index:       i,
+			Resource:    r.Resource,
+			Name:        r.Name,
+			Username:    r.Username,
+			URI:         r.URI,
+			Password:    r.Password,
+			Description: r.Description,
+		}
+	}
+	return decrypted
+}
+
+// FindResource returns the Resource referenced by an ID, Name or Path like "Prod/DB/root"
+func (s *Snapshot) FindResource(input string) (*SnapshotResource, error) {
+	id := input
+	if !util.IsUUID(input) {
+		var err error
+		id, err = resolveResourceIDFromList(folder.GetFolderPaths(s.Folders), s.Decrypted(), input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range s.Resources {
+		if strings.EqualFold(s.Resources[i].Resource.ID, id) {
+			return &s.Resources[i], nil
+		}
+	}
+	return nil, fmt.Errorf("Resource %v is not in the Snapshot", input)
+}
@@ -1,7 +1,9 @@
 package resource
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/helper"
@@ -10,25 +12,30 @@ import (
 
 // ResourceUpdateCmd Updates a Passbolt Resource
 var ResourceUpdateCmd = &cobra.Command{
-	Use:   "resource",
-	Short: "Updates a Passbolt Resource",
-	Long:  `Updates a Passbolt Resource`,
-	RunE:  ResourceUpdate,
+	Use:               "resource [id]",
+	Short:             "Updates a Passbolt Resource",
+	Long:              `Updates a Passbolt Resource`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeResourceArg,
+	RunE:              ResourceUpdate,
 }
 
 func init() {
-	ResourceUpdateCmd.Flags().String("id", "", "id of Resource to Update")
+	ResourceUpdateCmd.Flags().String("id", "", "id, name or path of Resource to Update")
 	ResourceUpdateCmd.Flags().StringP("name", "n", "", "Resource Name")
 	ResourceUpdateCmd.Flags().StringP("username", "u", "", "Resource Username")
 	ResourceUpdateCmd.Flags().String("uri", "", "Resource URI")
 	ResourceUpdateCmd.Flags().StringP("password", "p", "", "Resource Password")
+	ResourceUpdateCmd.Flags().Bool("generate", false, "Generate a new Resource Password, using the Password Policy of the Server if available")
 	ResourceUpdateCmd.Flags().StringP("description", "d", "", "Resource Description")
-	ResourceUpdateCmd.Flags().String("expiry", "", "Expiry as RFC3339 (e.g. 2025-12-31T23:59:59Z), duration (e.g. 7d, 12h), or 'none' to clear")
-	ResourceUpdateCmd.MarkFlagRequired("id")
+	ResourceUpdateCmd.Flags().String("expiry", "", "Expiry as RFC3339 (e.g. 2025-12-31T23:59:59Z), date (e.g. 2025-12-31), duration (e.g. 90d, 1w2d), phrase (e.g. end-of-quarter) or 'none' to clear")
+	ResourceUpdateCmd.RegisterFlagCompletionFunc("id", CompleteResourceIDs)
+	addBulkFlags(ResourceUpdateCmd)
+	util.AddGeneratorFlags(ResourceUpdateCmd)
 }
 
 func ResourceUpdate(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, filter, yes, err := getIDOrFilter(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -52,12 +59,32 @@ func ResourceUpdate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	generate, err := cmd.Flags().GetBool("generate")
+	if err != nil {
+		return err
+	}
+	if generate && password != "" {
+		return fmt.Errorf("Either --password or --generate can be given, not both")
+	}
 
 	expiry, err := cmd.Flags().GetString("expiry")
 	if err != nil {
 		return err
 	}
 
+	// Only setting the Expiry does not need to touch the Resource itself, which makes bulk Expiry Changes cheap
+	expiryOnly := name == "" && username == "" && uri == "" && password == "" && description == "" && !generate
+	if expiryOnly && expiry == "" {
+		return fmt.Errorf("Nothing to Update, set at least one Field or --expiry")
+	}
+	if expiry != "" && !strings.EqualFold(expiry, "none") {
+		// Fail before anything is updated
+		_, err = ParseExpiry(expiry)
+		if err != nil {
+			return err
+		}
+	}
+
 	ctx, cancel := util.GetContext()
 	defer cancel()
 
@@ -68,24 +95,58 @@ func ResourceUpdate(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
-	err = helper.UpdateResource(
-		ctx,
-		client,
-		id,
-		name,
-		username,
-		uri,
-		password,
-		description,
-	)
-	if err != nil {
-		return fmt.Errorf("Updating Resource: %w", err)
+	var policy util.GeneratorPolicy
+	if generate {
+		policy, err = util.GetGeneratorPolicy(ctx, cmd, client)
+		if err != nil {
+			return err
+		}
 	}
 
-	if expiry != "" {
-		if err := SetResourceExpiry(ctx, client, id, expiry); err != nil {
-			return err
+	update := func(ctx context.Context, id string) error {
+		password := password
+		if generate {
+			// Every Resource gets its own Password
+			var err error
+			password, err = util.GeneratePassword(policy)
+			if err != nil {
+				return fmt.Errorf("Generating Password: %w", err)
+			}
+		}
+
+		if !expiryOnly {
+			err := helper.UpdateResource(
+				ctx,
+				client,
+				id,
+				name,
+				username,
+				uri,
+				password,
+				description,
+			)
+			if err != nil {
+				return fmt.Errorf("Updating Resource: %w", err)
+			}
+		}
+
+		if expiry != "" {
+			if err := SetResourceExpiry(ctx, client, id, expiry); err != nil {
+				return err
+			}
 		}
+		return nil
+	}
+
+	if filter != "" {
+		return bulk(ctx, client, "Update", filter, yes, func(ctx context.Context, d DecryptedResource) error {
+			return update(ctx, d.Resource.ID)
+		})
+	}
+
+	id, err = ResolveResourceID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving Resource: %w", err)
 	}
-	return nil
+	return update(ctx, id)
 }
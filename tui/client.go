@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/passbolt/go-passbolt-cli/resource"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/api"
 	"github.com/passbolt/go-passbolt/helper"
@@ -20,6 +22,25 @@ type sessionClient struct {
 	cancel   context.CancelFunc
 	totpChan chan string // send TOTP code from TUI to MFA callback
 	needMFA  bool       // true if interactive TOTP login is needed in-TUI
+
+	// snapshot is set in offline mode, all data is then served from it
+	snapshot        *resource.Snapshot
+	snapshotUpdated time.Time
+}
+
+// newOfflineSessionClient serves resources from the local snapshot without contacting the server.
+func newOfflineSessionClient() (*sessionClient, error) {
+	snapshot, updated, err := resource.LoadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &sessionClient{
+		snapshot:        snapshot,
+		snapshotUpdated: updated,
+	}
+	sc.ctx, sc.cancel = context.WithCancel(context.Background())
+	return sc, nil
 }
 
 // newSessionClient creates the API client. For noninteractive or no-MFA modes
@@ -141,6 +162,8 @@ func newSessionClient() (*sessionClient, error) {
 }
 
 func (sc *sessionClient) close() {
-	util.SaveSessionKeysAndLogout(sc.ctx, sc.client)
+	if sc.snapshot == nil {
+		util.SaveSessionKeysAndLogout(sc.ctx, sc.client)
+	}
 	sc.cancel()
 }
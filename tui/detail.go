@@ -10,6 +10,23 @@ import (
 
 func loadDetailCmd(sc *sessionClient, resourceID, resourceName string) tea.Cmd {
 	return func() tea.Msg {
+		if sc.snapshot != nil {
+			r, err := sc.snapshot.FindResource(resourceID)
+			if err != nil {
+				return detailLoadedMsg{err: err}
+			}
+			return detailLoadedMsg{
+				data: &detailData{
+					name:        r.Name,
+					username:    r.Username,
+					uri:         r.URI,
+					password:    r.Password,
+					description: r.Description,
+					folderID:    r.Resource.FolderParentID,
+				},
+			}
+		}
+
 		folderParentID, name, username, uri, password, description, err := helper.GetResource(
 			sc.ctx,
 			sc.client,
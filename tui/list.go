@@ -8,15 +8,7 @@ import (
 
 func loadResourcesCmd(sc *sessionClient) tea.Cmd {
 	return func() tea.Msg {
-		resources, err := sc.client.GetResources(sc.ctx, &api.GetResourcesOptions{
-			ContainSecret: false,
-		})
-		if err != nil {
-			return resourcesLoadedMsg{err: err}
-		}
-
-		// Decrypt metadata (handles both v4 plaintext and v5 encrypted metadata).
-		decrypted, err := resource.DecryptResourcesParallel(sc.ctx, sc.client, resources, false)
+		decrypted, err := loadResources(sc)
 		if err != nil {
 			return resourcesLoadedMsg{err: err}
 		}
@@ -34,3 +26,19 @@ func loadResourcesCmd(sc *sessionClient) tea.Cmd {
 		return resourcesLoadedMsg{items: items}
 	}
 }
+
+func loadResources(sc *sessionClient) ([]resource.DecryptedResource, error) {
+	if sc.snapshot != nil {
+		return sc.snapshot.Decrypted(), nil
+	}
+
+	resources, err := sc.client.GetResources(sc.ctx, &api.GetResourcesOptions{
+		ContainSecret: false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt metadata (handles both v4 plaintext and v5 encrypted metadata).
+	return resource.DecryptResourcesParallel(sc.ctx, sc.client, resources, false)
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/passbolt/go-passbolt-cli/resource"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 type appState int
@@ -68,6 +70,10 @@ func newModel(sc *sessionClient, initialState appState) model {
 
 	l := list.New([]list.Item{}, delegate, 0, 0)
 	l.Title = "Resources"
+	if sc.snapshot != nil {
+		// Make clear that the data may be outdated
+		l.Title = "Resources (Offline Snapshot from " + resource.SnapshotAge(sc.snapshotUpdated) + ")"
+	}
 	l.SetShowHelp(false)
 	l.SetShowStatusBar(true)
 	l.Styles.Title = titleStyle
@@ -254,7 +260,12 @@ func (m model) View() string {
 
 // Run is the entry point called by the cobra command.
 func Run(cmd *cobra.Command, args []string) error {
-	sc, err := newSessionClient()
+	newSession := newSessionClient
+	if viper.GetBool("offline") {
+		newSession = newOfflineSessionClient
+	}
+
+	sc, err := newSession()
 	if err != nil {
 		return err
 	}
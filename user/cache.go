@@ -0,0 +1,20 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// CacheKind is the Name of the User Metadata Cache
+const CacheKind = "users"
+
+// FetchUsers fetches all Users for the Metadata Cache.
+func FetchUsers(ctx context.Context, client *api.Client, _ []User) ([]User, error) {
+	users, err := getUsers(ctx, client, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Users: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// CompleteUserIDs completes User IDs with their Names and Usernames as Description
+var CompleteUserIDs = cache.CompleteIDs("user", fetchUserCompletions)
+
+var completeUserArg = cache.CompleteArgIDs("user", fetchUserCompletions)
+
+func fetchUserCompletions(ctx context.Context, client *api.Client) ([]cache.Completion, error) {
+	users, err := client.GetUsers(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Users: %w", err)
+	}
+
+	completions := make([]cache.Completion, len(users))
+	for i, user := range users {
+		name := user.Username
+		if user.Profile != nil {
+			name = fmt.Sprintf("%v %v <%v>", user.Profile.FirstName, user.Profile.LastName, user.Username)
+		}
+		completions[i] = cache.Completion{
+			ID:   user.ID,
+			Name: name,
+		}
+	}
+	return completions, nil
+}
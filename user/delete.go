@@ -10,22 +10,25 @@ import (
 
 // UserDeleteCmd Deletes a User
 var UserDeleteCmd = &cobra.Command{
-	Use:   "user",
-	Short: "Deletes a Passbolt User",
-	Long:  `Deletes a Passbolt User`,
-	RunE:  UserDelete,
+	Use:               "user [id]",
+	Short:             "Deletes a Passbolt User",
+	Long:              `Deletes a Passbolt User`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeUserArg,
+	RunE:              UserDelete,
+}
+
+func init() {
+	UserDeleteCmd.Flags().String("id", "", "id, username or full name of User to Delete")
+	UserDeleteCmd.RegisterFlagCompletionFunc("id", CompleteUserIDs)
 }
 
 func UserDelete(cmd *cobra.Command, args []string) error {
-	resourceID, err := cmd.Flags().GetString("id")
+	resourceID, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
 
-	if resourceID == "" {
-		return fmt.Errorf("No ID to Delete Provided")
-	}
-
 	ctx, cancel := util.GetContext()
 	defer cancel()
 
@@ -36,6 +39,11 @@ func UserDelete(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	resourceID, err = util.ResolveUserID(ctx, client, resourceID)
+	if err != nil {
+		return fmt.Errorf("Resolving User: %w", err)
+	}
+
 	err = helper.DeleteUser(ctx, client, resourceID)
 	if err != nil {
 		return fmt.Errorf("Deleting User: %w", err)
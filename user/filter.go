@@ -6,10 +6,9 @@ import (
 
 	"github.com/google/cel-go/cel"
 	"github.com/passbolt/go-passbolt-cli/util"
-	"github.com/passbolt/go-passbolt/api"
 )
 
-// Environments for CEl
+// Environments for CEL
 var celEnvOptions = []cel.EnvOption{
 	cel.Variable("ID", cel.StringType),
 	cel.Variable("Username", cel.StringType),
@@ -18,10 +17,31 @@ var celEnvOptions = []cel.EnvOption{
 	cel.Variable("Role", cel.StringType),
 	cel.Variable("CreatedTimestamp", cel.TimestampType),
 	cel.Variable("ModifiedTimestamp", cel.TimestampType),
+	cel.Variable("Active", cel.BoolType),
+	cel.Variable("Disabled", cel.BoolType),
+	cel.Variable("Deleted", cel.BoolType),
+	cel.Variable("LastLoggedIn", cel.TimestampType),
+}
+
+// userCelVars returns the CEL activation for a user
+func userCelVars(user User) map[string]any {
+	return map[string]any{
+		"ID":                user.ID,
+		"Username":          user.Username,
+		"FirstName":         user.Profile.FirstName,
+		"LastName":          user.Profile.LastName,
+		"Role":              user.Role.Name,
+		"CreatedTimestamp":  user.Created.Time,
+		"ModifiedTimestamp": user.Modified.Time,
+		"Active":            user.Active,
+		"Disabled":          user.isDisabled(),
+		"Deleted":           user.Deleted,
+		"LastLoggedIn":      user.lastLoggedIn(),
+	}
 }
 
 // Filters the slice users by invoke CEL program for each user
-func filterUsers(users *[]api.User, celCmd string, ctx context.Context) ([]api.User, error) {
+func filterUsers(users *[]User, celCmd string, ctx context.Context) ([]User, error) {
 	if celCmd == "" {
 		return *users, nil
 	}
@@ -31,17 +51,9 @@ func filterUsers(users *[]api.User, celCmd string, ctx context.Context) ([]api.U
 		return nil, err
 	}
 
-	filteredUsers := []api.User{}
+	filteredUsers := []User{}
 	for _, user := range *users {
-		val, _, err := (*program).ContextEval(ctx, map[string]any{
-			"ID":                user.ID,
-			"Username":          user.Username,
-			"FirstName":         user.Profile.FirstName,
-			"LastName":          user.Profile.LastName,
-			"Role":              user.Role.Name,
-			"CreatedTimestamp":  user.Created.Time,
-			"ModifiedTimestamp": user.Modified.Time,
-		})
+		val, _, err := (*program).ContextEval(ctx, userCelVars(user))
 
 		if err != nil {
 			return nil, err
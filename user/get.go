@@ -12,20 +12,22 @@ import (
 
 // UserGetCmd Gets a Passbolt User
 var UserGetCmd = &cobra.Command{
-	Use:   "user",
-	Short: "Gets a Passbolt User",
-	Long:  `Gets a Passbolt User`,
-	RunE:  UserGet,
+	Use:               "user [id]",
+	Short:             "Gets a Passbolt User",
+	Long:              `Gets a Passbolt User`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeUserArg,
+	RunE:              UserGet,
 }
 
 func init() {
-	UserGetCmd.Flags().String("id", "", "id of User to Get")
+	UserGetCmd.Flags().String("id", "", "id, username or full name of User to Get")
 
-	UserGetCmd.MarkFlagRequired("id")
+	UserGetCmd.RegisterFlagCompletionFunc("id", CompleteUserIDs)
 }
 
 func UserGet(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -44,6 +46,11 @@ func UserGet(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
+	id, err = util.ResolveUserID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving User: %w", err)
+	}
+
 	role, username, firstname, lastname, err := helper.GetUser(
 		ctx,
 		client,
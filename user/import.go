@@ -0,0 +1,404 @@
+package user
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+	"github.com/spf13/cobra"
+)
+
+// UserImportCmd Imports Passbolt Users from a CSV File
+var UserImportCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Creates and Updates Passbolt Users from a CSV File",
+	Long: `Makes the Passbolt Users match a CSV File, e.g. an Export of your Identity Provider.
+The File needs a Header Row with the Columns username, firstname, lastname, role and groups (Group Names separated by ";").
+Missing Users are created, changed Names and Roles are updated, disabled Users are enabled and Users are added to their Groups.
+Empty Names and Roles are left unchanged, new Users without Role get the Role user. Your own Role is never changed.
+With --syncGroups Users are also removed from Groups of the File they are not listed for, with --disableMissing
+Users which are not in the File are disabled.
+Users who have not completed the Setup can't be added to Groups yet, run the Import again once they did.
+The Changes are shown and confirmed first, --dryRun only shows them.`,
+	Args: cobra.NoArgs,
+	RunE: UserImport,
+}
+
+func init() {
+	UserImportCmd.Flags().StringP("file", "f", "", "CSV File to Import")
+	UserImportCmd.Flags().Bool("syncGroups", false, "Also remove Users from Groups of the File they are not listed for")
+	UserImportCmd.Flags().Bool("disableMissing", false, "Disable Users which are not in the File")
+	UserImportCmd.Flags().Bool("dryRun", false, "Only show the Changes")
+	UserImportCmd.Flags().BoolP("yes", "y", false, "Don't ask for Confirmation before applying the Changes")
+	UserImportCmd.MarkFlagRequired("file")
+}
+
+// importUser is a single Row of the CSV File
+type importUser struct {
+	username  string
+	firstname string
+	lastname  string
+	role      string
+	groups    []string
+}
+
+// importChange is a single Step of an Import
+type importChange struct {
+	// symbol is "+" for creates, "~" for changes and "-" for disables
+	symbol  string
+	kind    string
+	name    string
+	details []string
+	apply   func(ctx context.Context) error
+}
+
+func (c importChange) String() string {
+	return fmt.Sprintf("%v %v %q", c.symbol, c.kind, c.name)
+}
+
+func UserImport(cmd *cobra.Command, args []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+	syncGroups, err := cmd.Flags().GetBool("syncGroups")
+	if err != nil {
+		return err
+	}
+	disableMissing, err := cmd.Flags().GetBool("disableMissing")
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool("dryRun")
+	if err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("Opening File: %w", err)
+	}
+	defer f.Close()
+	rows, err := readImportUsers(f)
+	if err != nil {
+		return fmt.Errorf("Reading %v: %w", file, err)
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	users, err := getUsers(ctx, client, nil)
+	if err != nil {
+		return fmt.Errorf("Listing Users: %w", err)
+	}
+	groups, err := client.GetGroups(ctx, &api.GetGroupsOptions{
+		ContainGroupsUsers: true,
+	})
+	if err != nil {
+		return fmt.Errorf("Listing Groups: %w", err)
+	}
+
+	changes, err := planImport(client, client.GetUserID(), rows, users, groups, syncGroups, disableMissing)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("No Changes, the Users match the File.")
+		return nil
+	}
+	counts := map[string]int{}
+	for _, c := range changes {
+		fmt.Println(c)
+		for _, detail := range c.details {
+			fmt.Printf("    %v\n", detail)
+		}
+		counts[c.symbol]++
+	}
+	fmt.Printf("\nImport: %d to create, %d to change, %d to disable.\n", counts["+"], counts["~"], counts["-"])
+	if dryRun {
+		return nil
+	}
+
+	err = util.Confirm(fmt.Sprintf("Apply %d Changes?", len(changes)), yes)
+	if err != nil {
+		return err
+	}
+
+	for i, c := range changes {
+		err = c.apply(ctx)
+		if err != nil {
+			return fmt.Errorf("Applying %v: %w (%d of %d Changes applied)", c, err, i, len(changes))
+		}
+		fmt.Printf("Applied %v\n", c)
+	}
+	fmt.Printf("Applied %d Changes\n", len(changes))
+	return nil
+}
+
+// readImportUsers reads the CSV File, Columns are matched by the Header Row
+func readImportUsers(r io.Reader) ([]importUser, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("The File is empty")
+		}
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["username"]; !ok {
+		return nil, fmt.Errorf("The Header Row has no username Column")
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	users := []importUser{}
+	seen := map[string]bool{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return users, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		u := importUser{
+			username:  field(record, "username"),
+			firstname: field(record, "firstname"),
+			lastname:  field(record, "lastname"),
+			role:      field(record, "role"),
+		}
+		if u.username == "" {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("Line %d has no username", line)
+		}
+		if seen[strings.ToLower(u.username)] {
+			return nil, fmt.Errorf("User %v is listed more than once", u.username)
+		}
+		seen[strings.ToLower(u.username)] = true
+		for _, g := range strings.Split(field(record, "groups"), ";") {
+			if g = strings.TrimSpace(g); g != "" {
+				u.groups = append(u.groups, g)
+			}
+		}
+		users = append(users, u)
+	}
+}
+
+// planImport returns the Changes needed to make the Users match the File.
+// User Changes come first, Group Changes are batched per Group.
+// me is the importing User, which is never disabled and whose Role is never changed.
+// Empty Fields are not managed by the File, only new Users get the Role user if none is given.
+func planImport(client *api.Client, me string, rows []importUser, users []User, groups []api.Group, syncGroups, disableMissing bool) ([]importChange, error) {
+	byUsername := map[string]User{}
+	for _, u := range users {
+		byUsername[strings.ToLower(u.Username)] = u
+	}
+	groupByName := map[string]api.Group{}
+	for _, g := range groups {
+		groupByName[strings.ToLower(g.Name)] = g
+	}
+
+	changes := []importChange{}
+	// wanted Group Memberships of active Users: Group ID -> User ID -> wanted
+	wanted := map[string]map[string]bool{}
+	// Groups mentioned in the File, only these are touched by --syncGroups
+	mentioned := map[string]bool{}
+	imported := map[string]bool{}
+	pending := []string{}
+
+	for _, row := range rows {
+		groupIDs := []string{}
+		for _, name := range row.groups {
+			g, ok := groupByName[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("User %v: Unknown Group %v", row.username, name)
+			}
+			groupIDs = append(groupIDs, g.ID)
+			mentioned[g.ID] = true
+		}
+
+		existing, ok := byUsername[strings.ToLower(row.username)]
+		if !ok {
+			role := row.role
+			if role == "" {
+				role = "user"
+			}
+			changes = append(changes, importChange{
+				symbol:  "+",
+				kind:    "user",
+				name:    row.username,
+				details: []string{fmt.Sprintf("Name: %v %v, Role: %v", row.firstname, row.lastname, role)},
+				apply: func(ctx context.Context) error {
+					_, err := helper.CreateUser(ctx, client, role, row.username, row.firstname, row.lastname)
+					return err
+				},
+			})
+			if len(groupIDs) != 0 {
+				pending = append(pending, row.username)
+			}
+			continue
+		}
+		imported[existing.ID] = true
+
+		details := []string{}
+		firstname, lastname, role := "", "", ""
+		if existing.Profile != nil && row.firstname != "" && row.firstname != existing.Profile.FirstName {
+			firstname = row.firstname
+			details = append(details, fmt.Sprintf("FirstName: %v -> %v", existing.Profile.FirstName, row.firstname))
+		}
+		if existing.Profile != nil && row.lastname != "" && row.lastname != existing.Profile.LastName {
+			lastname = row.lastname
+			details = append(details, fmt.Sprintf("LastName: %v -> %v", existing.Profile.LastName, row.lastname))
+		}
+		if existing.Role != nil && row.role != "" && !strings.EqualFold(row.role, existing.Role.Name) {
+			if existing.ID == me {
+				return nil, fmt.Errorf("User %v: Can't change your own Role from %v to %v", row.username, existing.Role.Name, row.role)
+			}
+			role = row.role
+			details = append(details, fmt.Sprintf("Role: %v -> %v", existing.Role.Name, row.role))
+		}
+		enable := existing.isDisabled()
+		if enable {
+			details = append(details, "Enable")
+		}
+		if len(details) != 0 {
+			id := existing.ID
+			changes = append(changes, importChange{
+				symbol:  "~",
+				kind:    "user",
+				name:    existing.Username,
+				details: details,
+				apply: func(ctx context.Context) error {
+					if firstname != "" || lastname != "" || role != "" {
+						err := helper.UpdateUser(ctx, client, id, role, firstname, lastname)
+						if err != nil {
+							return err
+						}
+					}
+					if enable {
+						return setUserDisabled(ctx, client, id, false)
+					}
+					return nil
+				},
+			})
+		}
+
+		// Secrets can only be shared with Users who completed the Setup
+		if !existing.Active {
+			if len(groupIDs) != 0 {
+				pending = append(pending, existing.Username)
+			}
+			continue
+		}
+		for _, groupID := range groupIDs {
+			if wanted[groupID] == nil {
+				wanted[groupID] = map[string]bool{}
+			}
+			wanted[groupID][existing.ID] = true
+		}
+	}
+
+	usernames := map[string]string{}
+	for _, u := range users {
+		usernames[u.ID] = u.Username
+	}
+	for _, g := range groups {
+		if !mentioned[g.ID] {
+			continue
+		}
+		members := map[string]bool{}
+		for _, m := range g.GroupUsers {
+			members[m.UserID] = true
+		}
+
+		ops := []helper.GroupMembershipOperation{}
+		details := []string{}
+		for userID := range wanted[g.ID] {
+			if !members[userID] {
+				ops = append(ops, helper.GroupMembershipOperation{UserID: userID})
+				details = append(details, "+ "+usernames[userID])
+			}
+		}
+		if syncGroups {
+			for _, m := range g.GroupUsers {
+				// Only Users from the File are removed, Managers are kept so the Group is not left without one
+				if imported[m.UserID] && !wanted[g.ID][m.UserID] && !m.IsAdmin {
+					ops = append(ops, helper.GroupMembershipOperation{UserID: m.UserID, Delete: true})
+					details = append(details, "- "+usernames[m.UserID])
+				}
+			}
+		}
+		if len(ops) == 0 {
+			continue
+		}
+		sort.Strings(details)
+
+		groupID := g.ID
+		changes = append(changes, importChange{
+			symbol:  "~",
+			kind:    "group",
+			name:    g.Name,
+			details: details,
+			apply: func(ctx context.Context) error {
+				return helper.UpdateGroup(ctx, client, groupID, "", ops)
+			},
+		})
+	}
+
+	if disableMissing {
+		for _, u := range users {
+			// Never lock out the importing User
+			if imported[u.ID] || u.ID == me || u.isDisabled() {
+				continue
+			}
+			id := u.ID
+			changes = append(changes, importChange{
+				symbol: "-",
+				kind:   "user",
+				name:   u.Username,
+				apply: func(ctx context.Context) error {
+					return setUserDisabled(ctx, client, id, true)
+				},
+			})
+		}
+	}
+
+	if len(pending) != 0 {
+		slices.Sort(pending)
+		fmt.Fprintf(os.Stderr, "Not adding to Groups until they completed the Setup: %v\n", strings.Join(pending, ", "))
+	}
+	return changes, nil
+}
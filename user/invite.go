@@ -0,0 +1,61 @@
+package user
+
+import (
+	"fmt"
+
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// UserResendInviteCmd Resends the Invitation of a Passbolt User
+var UserResendInviteCmd = &cobra.Command{
+	Use:               "resend-invite [id]",
+	Short:             "Resends the Invitation Email of a Passbolt User",
+	Long:              `Resends the Invitation Email of a Passbolt User who has not completed the Setup yet (see "list user --pending")`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeUserArg,
+	RunE:              UserResendInvite,
+}
+
+func init() {
+	UserResendInviteCmd.Flags().String("id", "", "id, username or full name of User to Invite again")
+	UserResendInviteCmd.RegisterFlagCompletionFunc("id", CompleteUserIDs)
+}
+
+func UserResendInvite(cmd *cobra.Command, args []string) error {
+	id, err := util.GetIDArg(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	id, err = util.ResolveUserID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving User: %w", err)
+	}
+
+	user, err := client.GetUser(ctx, id)
+	if err != nil {
+		return fmt.Errorf("Getting User: %w", err)
+	}
+	if user.Active {
+		return fmt.Errorf("User %v has already completed the Setup", user.Username)
+	}
+
+	// For Users who have not completed the Setup, the Server sends a new Invitation instead of a Recovery Email
+	_, err = client.DoCustomRequest(ctx, "POST", "/users/recover.json", "v2", map[string]string{"username": user.Username}, nil)
+	if err != nil {
+		return fmt.Errorf("Resending Invitation: %w", err)
+	}
+	fmt.Printf("Resent Invitation to %v\n", user.Username)
+	return nil
+}
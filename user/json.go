@@ -10,4 +10,8 @@ type UserJsonOutput struct {
 	Role              *string    `json:"role,omitempty"`
 	CreatedTimestamp  *time.Time `json:"created_timestamp,omitempty"`
 	ModifiedTimestamp *time.Time `json:"modified_timestamp,omitempty"`
+	Active            *bool      `json:"active,omitempty"`
+	Disabled          *bool      `json:"disabled,omitempty"`
+	Deleted           *bool      `json:"deleted,omitempty"`
+	LastLoggedIn      *time.Time `json:"last_logged_in,omitempty"`
 }
@@ -3,10 +3,12 @@ package user
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/cache"
 	"github.com/passbolt/go-passbolt-cli/util"
 	"github.com/passbolt/go-passbolt/api"
 	"github.com/spf13/cobra"
@@ -27,11 +29,12 @@ var UserListCmd = &cobra.Command{
 
 func init() {
 	flags := UserListCmd.Flags()
-	flags.StringArrayP("group", "g", []string{}, "Users that are members of groups")
+	flags.StringArrayP("group", "g", []string{}, "Users that are members of groups, as id or name")
 	flags.StringArrayP("resource", "r", []string{}, "Users that have access to resources")
 	flags.StringP("search", "s", "", "Search for Users")
 	flags.BoolP("admin", "a", false, "Only show Admins")
-	flags.StringArrayP("column", "c", defaultTableColumns, "Columns to return (default list only for table format; JSON format includes all fields by default).\nPossible Columns: ID, Username, FirstName, LastName, Role, CreatedTimestamp, ModifiedTimestamp")
+	flags.Bool("pending", false, "Only show Users who have not completed the Setup yet")
+	flags.StringArrayP("column", "c", defaultTableColumns, "Columns to return (default list only for table format; JSON format includes all fields by default).\nPossible Columns: ID, Username, FirstName, LastName, Role, CreatedTimestamp, ModifiedTimestamp, Active, Disabled, Deleted, LastLoggedIn")
 }
 
 type userListConfig struct {
@@ -39,10 +42,14 @@ type userListConfig struct {
 	resources      []string
 	search         string
 	admin          bool
+	pending        bool
 	columns        []string
 	columnsChanged bool
 	jsonOutput     bool
 	celFilter      string
+	sortKeys       []util.SortKey
+	limit          int
+	offset         int
 }
 
 func UserList(cmd *cobra.Command, args []string) error {
@@ -54,21 +61,52 @@ func UserList(cmd *cobra.Command, args []string) error {
 	ctx, cancel := util.GetContext()
 	defer cancel()
 
-	client, err := util.GetClient(ctx)
-	if err != nil {
-		return err
+	var users []User
+	// Server side Filters can't be applied to the Cache
+	if cache.Enabled() && len(config.groups) == 0 && len(config.resources) == 0 && config.search == "" && !config.admin {
+		client, err := cache.NewClient()
+		if err != nil {
+			return err
+		}
+		defer client.Logout(ctx)
+		cmd.SilenceUsage = true
+
+		users, err = cache.List(ctx, client, CacheKind, FetchUsers)
+		if err != nil {
+			return err
+		}
+	} else {
+		client, err := util.GetClient(ctx)
+		if err != nil {
+			return err
+		}
+		defer util.SaveSessionKeysAndLogout(ctx, client)
+		cmd.SilenceUsage = true
+
+		config.groups, err = util.ResolveGroupIDs(ctx, client, config.groups)
+		if err != nil {
+			return fmt.Errorf("Resolving Groups: %w", err)
+		}
+
+		users, err = getUsers(ctx, client, &api.GetUsersOptions{
+			FilterHasGroup:  config.groups,
+			FilterHasAccess: config.resources,
+			FilterSearch:    config.search,
+			FilterIsAdmin:   config.admin,
+		})
+		if err != nil {
+			return fmt.Errorf("Listing User: %w", err)
+		}
 	}
-	defer util.SaveSessionKeysAndLogout(ctx, client)
-	cmd.SilenceUsage = true
 
-	users, err := client.GetUsers(ctx, &api.GetUsersOptions{
-		FilterHasGroup:  config.groups,
-		FilterHasAccess: config.resources,
-		FilterSearch:    config.search,
-		FilterIsAdmin:   config.admin,
-	})
-	if err != nil {
-		return fmt.Errorf("Listing User: %w", err)
+	if config.pending {
+		pending := []User{}
+		for _, user := range users {
+			if !user.Active {
+				pending = append(pending, user)
+			}
+		}
+		users = pending
 	}
 
 	users, err = filterUsers(&users, config.celFilter, ctx)
@@ -76,6 +114,12 @@ func UserList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	err = util.SortByKeys(ctx, users, config.sortKeys, userCelVars, celEnvOptions...)
+	if err != nil {
+		return err
+	}
+	users = util.Paginate(users, config.offset, config.limit)
+
 	if config.jsonOutput {
 		return printJsonUsers(users, config.columnsChanged, config.columns)
 	}
@@ -83,9 +127,10 @@ func UserList(cmd *cobra.Command, args []string) error {
 	return printTableUsers(config.columns, users)
 }
 
-func printJsonUsers(users []api.User, isColumnsChanged bool, columns []string) error {
+func printJsonUsers(users []User, isColumnsChanged bool, columns []string) error {
 	outputUsers := make([]UserJsonOutput, len(users))
 	for i := range users {
+		disabled := users[i].isDisabled()
 		outputUsers[i] = UserJsonOutput{
 			ID:                &users[i].ID,
 			Username:          &users[i].Username,
@@ -94,6 +139,12 @@ func printJsonUsers(users []api.User, isColumnsChanged bool, columns []string) e
 			Role:              &users[i].Role.Name,
 			CreatedTimestamp:  &users[i].Created.Time,
 			ModifiedTimestamp: &users[i].Modified.Time,
+			Active:            &users[i].Active,
+			Disabled:          &disabled,
+			Deleted:           &users[i].Deleted,
+		}
+		if lastLoggedIn := users[i].lastLoggedIn(); !lastLoggedIn.IsZero() {
+			outputUsers[i].LastLoggedIn = &lastLoggedIn
 		}
 	}
 
@@ -131,7 +182,7 @@ func printJsonUsers(users []api.User, isColumnsChanged bool, columns []string) e
 	return nil
 }
 
-func printTableUsers(columns []string, users []api.User) error {
+func printTableUsers(columns []string, users []User) error {
 	data := pterm.TableData{columns}
 
 	for _, user := range users {
@@ -152,6 +203,16 @@ func printTableUsers(columns []string, users []api.User) error {
 				entry[i] = user.Created.Format(time.RFC3339)
 			case "modifiedtimestamp":
 				entry[i] = user.Modified.Format(time.RFC3339)
+			case "active":
+				entry[i] = strconv.FormatBool(user.Active)
+			case "disabled":
+				entry[i] = strconv.FormatBool(user.isDisabled())
+			case "deleted":
+				entry[i] = strconv.FormatBool(user.Deleted)
+			case "lastloggedin":
+				if lastLoggedIn := user.lastLoggedIn(); !lastLoggedIn.IsZero() {
+					entry[i] = lastLoggedIn.Format(time.RFC3339)
+				}
 			default:
 				return fmt.Errorf("Unknown Column: %v", columns[i])
 			}
@@ -180,6 +241,10 @@ func parseUserListFlags(cmd *cobra.Command) (*userListConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	pending, err := cmd.Flags().GetBool("pending")
+	if err != nil {
+		return nil, err
+	}
 	columns, err := cmd.Flags().GetStringArray("column")
 	if err != nil {
 		return nil, err
@@ -195,15 +260,23 @@ func parseUserListFlags(cmd *cobra.Command) (*userListConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	sortKeys, limit, offset, err := util.GetSortAndPagination(cmd, celEnvOptions...)
+	if err != nil {
+		return nil, err
+	}
 
 	return &userListConfig{
 		groups:         groups,
 		resources:      resources,
 		search:         search,
 		admin:          admin,
+		pending:        pending,
 		columns:        columns,
 		columnsChanged: cmd.Flags().Changed("column"),
 		jsonOutput:     jsonOutput,
 		celFilter:      celFilter,
+		sortKeys:       sortKeys,
+		limit:          limit,
+		offset:         offset,
 	}, nil
 }
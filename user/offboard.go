@@ -0,0 +1,335 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt-cli/cache"
+	"github.com/passbolt/go-passbolt-cli/util"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// UserOffboardCmd Offboards a Passbolt User
+var UserOffboardCmd = &cobra.Command{
+	Use:   "user [id]",
+	Short: "Offboards a Passbolt User, transferring their Ownerships",
+	Long: `Offboards a Passbolt User: Resources and Folders the User is the sole Owner of and Groups the User is the sole Manager of
+are transferred to --transferTo, then the User is deleted.
+Ownership can only be transferred to a User or Group (prefixed with "group:") which already has access, as Secrets can't be re-encrypted
+for others, and Groups can only be transferred to a User who is a Member. The Plan is shown and confirmed first, --dryRun only shows it.
+With --disable the User is disabled instead of deleted. Passbolt only transfers Ownership when deleting, so the existing Permissions and
+Memberships of --transferTo are upgraded to Owner and Manager first.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeUserArg,
+	RunE:              UserOffboard,
+}
+
+func init() {
+	UserOffboardCmd.Flags().String("id", "", "id, username or full name of User to Offboard")
+	UserOffboardCmd.Flags().StringP("transferTo", "t", "", "User or Group (prefixed with \"group:\") which gets the Ownerships of the User")
+	UserOffboardCmd.Flags().Bool("disable", false, "Disable the User instead of deleting it")
+	UserOffboardCmd.Flags().Bool("dryRun", false, "Only show what would be transferred")
+	UserOffboardCmd.Flags().BoolP("yes", "y", false, "Don't ask for Confirmation")
+	UserOffboardCmd.RegisterFlagCompletionFunc("id", CompleteUserIDs)
+	UserOffboardCmd.RegisterFlagCompletionFunc("transferTo", completeTransferTo)
+}
+
+// completeGroupIDs completes Group IDs, the group package imports this one so it can't be used here.
+// The Cache is shared with group.CompleteGroupIDs.
+var completeGroupIDs = cache.CompleteIDs("group", func(ctx context.Context, client *api.Client) ([]cache.Completion, error) {
+	groups, err := client.GetGroups(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Listing Groups: %w", err)
+	}
+
+	completions := make([]cache.Completion, len(groups))
+	for i, group := range groups {
+		completions[i] = cache.Completion{
+			ID:   group.ID,
+			Name: group.Name,
+		}
+	}
+	return completions, nil
+})
+
+// completeTransferTo completes Users and Groups prefixed with "group:", as accepted by util.ResolvePrincipal
+func completeTransferTo(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if input, ok := strings.CutPrefix(toComplete, "group:"); ok {
+		groups, directive := completeGroupIDs(cmd, args, input)
+		return prefixGroups(groups), directive
+	}
+	result, directive := CompleteUserIDs(cmd, args, toComplete)
+	if strings.HasPrefix("group:", toComplete) {
+		groups, _ := completeGroupIDs(cmd, args, "")
+		result = append(result, prefixGroups(groups)...)
+	}
+	return result, directive
+}
+
+func prefixGroups(groups []string) []string {
+	for i := range groups {
+		groups[i] = "group:" + groups[i]
+	}
+	return groups
+}
+
+// soleOwned is a Resource or Folder which would be left without Owner
+type soleOwned struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Permissions []api.Permission `json:"permissions"`
+}
+
+// soleManaged is a Group which would be left without Manager
+type soleManaged struct {
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	GroupsUsers []api.GroupMembership `json:"groups_users"`
+}
+
+// deleteConflicts is the Body of a failed Delete Dry Run
+type deleteConflicts struct {
+	Errors struct {
+		Resources struct {
+			SoleOwner []soleOwned `json:"sole_owner"`
+		} `json:"resources"`
+		Folders struct {
+			SoleOwner []soleOwned `json:"sole_owner"`
+		} `json:"folders"`
+		Groups struct {
+			SoleManager []soleManaged `json:"sole_manager"`
+		} `json:"groups"`
+	} `json:"errors"`
+}
+
+type ownerTransfer struct {
+	ID            string `json:"id"`
+	ACOForeignKey string `json:"aco_foreign_key"`
+}
+
+type managerTransfer struct {
+	ID      string `json:"id"`
+	GroupID string `json:"group_id"`
+}
+
+// userTransfer is the Ownership Transfer sent when deleting a User
+type userTransfer struct {
+	Owners   []ownerTransfer   `json:"owners,omitempty"`
+	Managers []managerTransfer `json:"managers,omitempty"`
+}
+
+// offboardItem is a single Row of the Offboarding Plan
+type offboardItem struct {
+	kind    string
+	id      string
+	name    string
+	problem string
+}
+
+func UserOffboard(cmd *cobra.Command, args []string) error {
+	id, err := util.GetIDArg(cmd, args)
+	if err != nil {
+		return err
+	}
+	transferTo, err := cmd.Flags().GetString("transferTo")
+	if err != nil {
+		return err
+	}
+	disable, err := cmd.Flags().GetBool("disable")
+	if err != nil {
+		return err
+	}
+	dryRun, err := cmd.Flags().GetBool("dryRun")
+	if err != nil {
+		return err
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := util.GetContext()
+	defer cancel()
+
+	client, err := util.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer util.SaveSessionKeysAndLogout(ctx, client)
+	cmd.SilenceUsage = true
+
+	id, err = util.ResolveUserID(ctx, client, id)
+	if err != nil {
+		return fmt.Errorf("Resolving User: %w", err)
+	}
+	user, err := client.GetUser(ctx, id)
+	if err != nil {
+		return fmt.Errorf("Getting User: %w", err)
+	}
+
+	var aro, aroID string
+	if transferTo != "" {
+		aro, aroID, err = util.ResolvePrincipal(ctx, client, transferTo)
+		if err != nil {
+			return err
+		}
+		if aro == "User" && aroID == id {
+			return fmt.Errorf("Can't transfer to the offboarded User")
+		}
+	}
+
+	conflicts, err := getDeleteConflicts(ctx, client, id)
+	if err != nil {
+		return err
+	}
+	transfer, items := planTransfer(conflicts, aro, aroID)
+
+	if len(items) == 0 {
+		fmt.Printf("%v owns nothing alone\n", shellescape.StripUnsafe(user.Username))
+	} else {
+		err = printOffboardPlan(items)
+		if err != nil {
+			return err
+		}
+	}
+
+	failed := slices.ContainsFunc(items, func(i offboardItem) bool { return i.problem != "" })
+	if failed {
+		if transferTo == "" {
+			return fmt.Errorf("The User is the sole Owner or Manager of %d Items, use --transferTo", len(items))
+		}
+		return fmt.Errorf("Can't transfer all Items to %v, give it access first", transferTo)
+	}
+	if dryRun {
+		return nil
+	}
+
+	action := "Delete"
+	if disable {
+		action = "Disable"
+	}
+	err = util.Confirm(fmt.Sprintf("%v User %v?", action, user.Username), yes)
+	if err != nil {
+		return err
+	}
+
+	if disable {
+		err = grantOwnerships(ctx, client, items, aro, aroID)
+		if err != nil {
+			return err
+		}
+		return setUserDisabled(ctx, client, id, true)
+	}
+
+	var body any
+	if len(transfer.Owners) != 0 || len(transfer.Managers) != 0 {
+		body = map[string]userTransfer{"transfer": transfer}
+	}
+	// TODO: Should be handled in go-passbolt once helper.DeleteUser supports Transfers
+	_, err = client.DoCustomRequest(ctx, "DELETE", "/users/"+id+".json", "v2", body, nil)
+	if err != nil {
+		return fmt.Errorf("Deleting User: %w", err)
+	}
+	return nil
+}
+
+// getDeleteConflicts runs a Delete Dry Run and returns what the User owns or manages alone
+func getDeleteConflicts(ctx context.Context, client *api.Client, id string) (*deleteConflicts, error) {
+	conflicts := &deleteConflicts{}
+	_, res, err := client.DoCustomRequestAndReturnRawResponse(ctx, "DELETE", "/users/"+id+"/dry-run.json", "v2", nil, nil)
+	if err == nil {
+		return conflicts, nil
+	}
+	// The Dry Run fails with the Conflicts in the Body
+	if res == nil || res.Header.Code != 400 || json.Unmarshal(res.Body, conflicts) != nil {
+		return nil, fmt.Errorf("Checking User Deletion: %w", err)
+	}
+	return conflicts, nil
+}
+
+// planTransfer finds the Permission or Group Membership of aro/aroID for every Conflict.
+// Items without a matching Permission or Membership are returned with a Problem.
+func planTransfer(conflicts *deleteConflicts, aro, aroID string) (userTransfer, []offboardItem) {
+	transfer := userTransfer{}
+	items := []offboardItem{}
+
+	owned := func(kind string, entities []soleOwned) {
+		for _, e := range entities {
+			item := offboardItem{kind: kind, id: e.ID, name: e.Name}
+			if item.name == "" {
+				item.name = e.ID
+			}
+			i := slices.IndexFunc(e.Permissions, func(p api.Permission) bool {
+				return p.ARO == aro && p.AROForeignKey == aroID
+			})
+			if i == -1 {
+				item.problem = "no Access"
+			} else {
+				transfer.Owners = append(transfer.Owners, ownerTransfer{ID: e.Permissions[i].ID, ACOForeignKey: e.ID})
+			}
+			items = append(items, item)
+		}
+	}
+	owned("Resource", conflicts.Errors.Resources.SoleOwner)
+	owned("Folder", conflicts.Errors.Folders.SoleOwner)
+
+	for _, g := range conflicts.Errors.Groups.SoleManager {
+		item := offboardItem{kind: "Group", id: g.ID, name: g.Name}
+		i := slices.IndexFunc(g.GroupsUsers, func(m api.GroupMembership) bool {
+			return aro == "User" && m.UserID == aroID
+		})
+		if i == -1 {
+			item.problem = "no Member"
+		} else {
+			transfer.Managers = append(transfer.Managers, managerTransfer{ID: g.GroupsUsers[i].ID, GroupID: g.ID})
+		}
+		items = append(items, item)
+	}
+
+	if aro == "" {
+		for i := range items {
+			items[i].problem = "no --transferTo"
+		}
+	}
+	return transfer, items
+}
+
+// grantOwnerships upgrades the Permission or Group Membership of aro/aroID in every Item to Owner or Manager.
+// This transfers the Ownerships of a User who is disabled instead of deleted, Passbolt only transfers them when deleting.
+func grantOwnerships(ctx context.Context, client *api.Client, items []offboardItem, aro, aroID string) error {
+	for _, item := range items {
+		var err error
+		owner := []helper.ShareOperation{{Type: util.PermissionOwner, ARO: aro, AROID: aroID}}
+		switch item.kind {
+		case "Resource":
+			err = helper.ShareResource(ctx, client, item.id, owner)
+		case "Folder":
+			err = helper.ShareFolder(ctx, client, item.id, owner)
+		case "Group":
+			err = helper.UpdateGroup(ctx, client, item.id, "", []helper.GroupMembershipOperation{{UserID: aroID, IsGroupManager: true}})
+		}
+		if err != nil {
+			return fmt.Errorf("Transferring %v %v: %w", item.kind, item.name, err)
+		}
+	}
+	return nil
+}
+
+func printOffboardPlan(items []offboardItem) error {
+	data := pterm.TableData{{"Type", "Name", "Transfer"}}
+	for _, item := range items {
+		status := "ok"
+		if item.problem != "" {
+			status = "not possible: " + item.problem
+		}
+		data = append(data, []string{item.kind, shellescape.StripUnsafe(item.name), status})
+	}
+	return pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+}
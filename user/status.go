@@ -0,0 +1,68 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// User is a Passbolt User including its Disabled Status, which go-passbolt does not expose yet
+type User struct {
+	api.User
+	// Disabled is when the User was disabled, nil if the User is enabled
+	Disabled *api.Time `json:"disabled,omitempty"`
+}
+
+// getUsers gets all Users including their Status and last Login
+// TODO: Should be handled in go-passbolt once api.User contains the Disabled Status
+func getUsers(ctx context.Context, client *api.Client, opts *api.GetUsersOptions) ([]User, error) {
+	if opts == nil {
+		opts = &api.GetUsersOptions{}
+	}
+	opts.ContainLastLoggedIn = true
+
+	msg, err := client.DoCustomRequest(ctx, "GET", "/users.json", "v2", nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []User
+	err = json.Unmarshal(msg.Body, &users)
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// setUserDisabled disables or enables a User, disabled Users can't log in but keep their Permissions
+func setUserDisabled(ctx context.Context, client *api.Client, id string, disabled bool) error {
+	var value *string
+	if disabled {
+		now := time.Now().UTC().Format(time.RFC3339)
+		value = &now
+	}
+
+	// TODO: Should be handled in go-passbolt once api.User contains the Disabled Status
+	_, err := client.DoCustomRequest(ctx, "PUT", "/users/"+id+".json", "v2", map[string]*string{"disabled": value}, nil)
+	if err != nil {
+		return fmt.Errorf("Updating Disabled Status: %w", err)
+	}
+	return nil
+}
+
+// isDisabled reports if the User is disabled, a Disabled Timestamp in the Future is not effective yet
+func (u User) isDisabled() bool {
+	return u.Disabled != nil && !u.Disabled.After(time.Now())
+}
+
+// lastLoggedIn returns when the User last logged in, the zero Time if never
+func (u User) lastLoggedIn() time.Time {
+	t, err := time.Parse(time.RFC3339, u.LastLoggedIn)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
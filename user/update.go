@@ -10,23 +10,27 @@ import (
 
 // UserUpdateCmd Updates a Passbolt User
 var UserUpdateCmd = &cobra.Command{
-	Use:   "user",
-	Short: "Updates a Passbolt User",
-	Long:  `Updates a Passbolt User`,
-	RunE:  UserUpdate,
+	Use:               "user [id]",
+	Short:             "Updates a Passbolt User",
+	Long:              `Updates a Passbolt User`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeUserArg,
+	RunE:              UserUpdate,
 }
 
 func init() {
-	UserUpdateCmd.Flags().String("id", "", "id of User to Update")
+	UserUpdateCmd.Flags().String("id", "", "id, username or full name of User to Update")
 	UserUpdateCmd.Flags().StringP("firstname", "f", "", "User FirstName")
 	UserUpdateCmd.Flags().StringP("lastname", "l", "", "User LastName")
 	UserUpdateCmd.Flags().StringP("role", "r", "", "User Role")
+	UserUpdateCmd.Flags().Bool("disable", false, "Disable the User, disabled Users can't log in but keep their Permissions")
+	UserUpdateCmd.Flags().Bool("enable", false, "Enable a disabled User again")
 
-	UserUpdateCmd.MarkFlagRequired("id")
+	UserUpdateCmd.RegisterFlagCompletionFunc("id", CompleteUserIDs)
 }
 
 func UserUpdate(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetString("id")
+	id, err := util.GetIDArg(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -42,6 +46,21 @@ func UserUpdate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	disable, err := cmd.Flags().GetBool("disable")
+	if err != nil {
+		return err
+	}
+	enable, err := cmd.Flags().GetBool("enable")
+	if err != nil {
+		return err
+	}
+	if disable && enable {
+		return fmt.Errorf("--disable and --enable can't be combined")
+	}
+	statusOnly := firstname == "" && lastname == "" && role == ""
+	if statusOnly && !disable && !enable {
+		return fmt.Errorf("Nothing to Update")
+	}
 
 	ctx, cancel := util.GetContext()
 	defer cancel()
@@ -53,16 +72,30 @@ func UserUpdate(cmd *cobra.Command, args []string) error {
 	defer util.SaveSessionKeysAndLogout(ctx, client)
 	cmd.SilenceUsage = true
 
-	err = helper.UpdateUser(
-		ctx,
-		client,
-		id,
-		role,
-		firstname,
-		lastname,
-	)
+	id, err = util.ResolveUserID(ctx, client, id)
 	if err != nil {
-		return fmt.Errorf("Updating User: %w", err)
+		return fmt.Errorf("Resolving User: %w", err)
+	}
+
+	if !statusOnly {
+		err = helper.UpdateUser(
+			ctx,
+			client,
+			id,
+			role,
+			firstname,
+			lastname,
+		)
+		if err != nil {
+			return fmt.Errorf("Updating User: %w", err)
+		}
+	}
+
+	if disable || enable {
+		err = setUserDisabled(ctx, client, id, disable)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,57 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+// ActionLog is an Entry of the Servers Action Log
+type ActionLog struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Created *api.Time       `json:"created"`
+	Creator *api.User       `json:"creator"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// GetActionLogsOptions are the Paging Options of the Action Log Endpoints
+type GetActionLogsOptions struct {
+	Limit int `url:"limit,omitempty"`
+	Page  int `url:"page,omitempty"`
+}
+
+// GetActionLogs gets one Page of Action Logs from path, e.g. "/actionlog/resource/<id>.json".
+// The Server only provides Action Logs per Entity (since Passbolt v3), there is no Endpoint listing those of the whole Instance.
+// TODO: Should be handled in go-passbolt once it supports Action Logs
+func GetActionLogs(ctx context.Context, client *api.Client, path string, opts GetActionLogsOptions) ([]ActionLog, error) {
+	msg, err := client.DoCustomRequest(ctx, "GET", path, "v2", nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []ActionLog
+	err = json.Unmarshal(msg.Body, &logs)
+	if err != nil {
+		return nil, fmt.Errorf("Parsing Action Logs: %w", err)
+	}
+	return logs, nil
+}
+
+// ActionLogDetails summarizes the Data of an Action Log Entry, currently only Permission Changes are described
+func ActionLogDetails(data json.RawMessage) string {
+	var parsed struct {
+		Permissions *struct {
+			Added   []json.RawMessage `json:"added"`
+			Updated []json.RawMessage `json:"updated"`
+			Removed []json.RawMessage `json:"removed"`
+		} `json:"permissions"`
+	}
+	if json.Unmarshal(data, &parsed) != nil || parsed.Permissions == nil {
+		return ""
+	}
+	p := parsed.Permissions
+	return fmt.Sprintf("%d added, %d updated, %d removed Permissions", len(p.Added), len(p.Updated), len(p.Removed))
+}
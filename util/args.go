@@ -0,0 +1,25 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// GetIDArg returns the ID given either via the --id Flag or as the first Positional Argument.
+func GetIDArg(cmd *cobra.Command, args []string) (string, error) {
+	id, err := cmd.Flags().GetString("id")
+	if err != nil {
+		return "", err
+	}
+	if len(args) != 0 {
+		if id != "" {
+			return "", fmt.Errorf("The ID can't be given both via --id and as Argument")
+		}
+		id = args[0]
+	}
+	if id == "" {
+		return "", fmt.Errorf("No ID Provided, use --id or pass it as Argument")
+	}
+	return id, nil
+}
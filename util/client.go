@@ -57,8 +57,9 @@ func SaveSessionKeysAndLogout(ctx context.Context, client *api.Client) {
 	client.Logout(ctx)
 }
 
-// GetClient gets a Logged in Passbolt Client
-func GetClient(ctx context.Context) (*api.Client, error) {
+// NewClient creates a Passbolt Client with the Users Private Key unlocked, without logging in.
+// If interactive is false and no password is configured, an error is returned instead of prompting.
+func NewClient(interactive bool) (*api.Client, error) {
 	serverAddress := viper.GetString("serverAddress")
 	if serverAddress == "" {
 		return nil, fmt.Errorf("serverAddress is not defined")
@@ -71,6 +72,9 @@ func GetClient(ctx context.Context) (*api.Client, error) {
 
 	userPassword := viper.GetString("userPassword")
 	if userPassword == "" {
+		if !interactive {
+			return nil, fmt.Errorf("userPassword is not defined")
+		}
 		cliPassword, err := ReadPassword("Enter Password:")
 		if err != nil {
 			fmt.Println()
@@ -91,14 +95,42 @@ func GetClient(ctx context.Context) (*api.Client, error) {
 	}
 
 	client.Debug = viper.GetBool("debug")
+	return client, nil
+}
+
+// CanLoginNoninteractive reports if logging in is possible without prompting the User
+func CanLoginNoninteractive() bool {
+	return viper.GetString("userPassword") != "" && viper.GetString("mfaMode") != "interactive-totp"
+}
+
+// GetClient gets a Logged in Passbolt Client
+func GetClient(ctx context.Context) (*api.Client, error) {
+	client, err := NewClient(true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = Login(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// Login verifies the Server, sets up MFA and logs in a Client created by NewClient
+func Login(ctx context.Context, client *api.Client) error {
+	// Offline Mode only serves Data from the local Snapshot, this also refuses all writes
+	if viper.GetBool("offline") {
+		return fmt.Errorf("This Command is not available in Offline Mode")
+	}
 
 	token := viper.GetString("serverVerifyToken")
 	encToken := viper.GetString("serverVerifyEncToken")
 
 	if token != "" {
-		err = client.VerifyServer(ctx, token, encToken)
+		err := client.VerifyServer(ctx, token, encToken)
 		if err != nil {
-			return nil, fmt.Errorf("Verifing Server: %w", err)
+			return fmt.Errorf("Verifing Server: %w", err)
 		}
 	}
 
@@ -160,9 +192,9 @@ func GetClient(ctx context.Context) (*api.Client, error) {
 	default:
 	}
 
-	err = client.Login(ctx)
+	err := client.Login(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Logging in: %w", err)
+		return fmt.Errorf("Logging in: %w", err)
 	}
-	return client, nil
+	return nil
 }
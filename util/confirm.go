@@ -0,0 +1,34 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Confirm asks the User to confirm a destructive Action, unless yes is already set (--yes).
+// Without a Terminal there is nobody to ask, so the Action is refused.
+func Confirm(prompt string, yes bool) error {
+	if yes {
+		return nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("Not running interactively, use --yes to confirm")
+	}
+
+	fmt.Fprintf(os.Stderr, "%v [y/N]: ", prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("Reading Confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("Aborted")
+}
@@ -0,0 +1,135 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationPartRegex matches one Part of a Duration like "2w" or "3mo"
+var durationPartRegex = regexp.MustCompile(`(\d+)(mo|y|w|d|h|m|s)`)
+
+// calendarDuration is a Duration made of Calendar Units (which vary in Length) and a fixed Clock Part
+type calendarDuration struct {
+	years, months, days int
+	clock               time.Duration
+}
+
+// parseCalendarDuration parses Durations like "7d", "1w2d3h" or "1y6mo".
+// Units are y (Years), mo (Months), w (Weeks), d (Days), h (Hours), m (Minutes) and s (Seconds).
+// Anything else time.ParseDuration understands (e.g. "1.5h") is accepted as well.
+func parseCalendarDuration(input string) (calendarDuration, error) {
+	var d calendarDuration
+	matches := durationPartRegex.FindAllStringSubmatchIndex(input, -1)
+
+	pos := 0
+	for _, m := range matches {
+		if m[0] != pos {
+			break
+		}
+		n, err := strconv.Atoi(input[m[2]:m[3]])
+		if err != nil {
+			return d, fmt.Errorf("invalid duration %q: %w", input, err)
+		}
+		switch input[m[4]:m[5]] {
+		case "y":
+			d.years += n
+		case "mo":
+			d.months += n
+		case "w":
+			d.days += 7 * n
+		case "d":
+			d.days += n
+		case "h":
+			d.clock += time.Duration(n) * time.Hour
+		case "m":
+			d.clock += time.Duration(n) * time.Minute
+		case "s":
+			d.clock += time.Duration(n) * time.Second
+		}
+		pos = m[1]
+	}
+	if pos == len(input) && pos != 0 {
+		return d, nil
+	}
+
+	clock, err := time.ParseDuration(input)
+	if err != nil {
+		return d, fmt.Errorf("invalid duration %q, use e.g. 7d, 2w, 3mo, 1y or combinations like 1w2d3h", input)
+	}
+	return calendarDuration{clock: clock}, nil
+}
+
+// ParseDuration parses a Duration using the same Grammar as ParseRelativeTime, e.g. "14d" or "1w2d3h".
+// As a Duration has no fixed Start, a Month counts as 30 Days and a Year as 365 Days.
+func ParseDuration(input string) (time.Duration, error) {
+	d, err := parseCalendarDuration(strings.TrimSpace(input))
+	if err != nil {
+		return 0, err
+	}
+	day := 24 * time.Hour
+	return time.Duration(d.years)*365*day + time.Duration(d.months)*30*day + time.Duration(d.days)*day + d.clock, nil
+}
+
+// ParseRelativeTime parses an absolute or relative Point in Time:
+//   - RFC3339 Timestamps like "2025-12-31T23:59:59Z"
+//   - Calendar Dates like "2025-12-31", meaning the Start of that Day in local Time
+//   - Durations from now like "7d", "3mo" or "1w2d3h", see ParseDuration
+//   - the Phrases "now", "today", "tomorrow", "end-of-day", "end-of-week", "end-of-month", "end-of-quarter" and "end-of-year",
+//     where the End is the last Second of the current Day, ISO Week (ending Sunday), Month, Quarter or Year
+func ParseRelativeTime(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	for _, layout := range []string{time.RFC3339, time.RFC3339Nano} {
+		if t, err := time.Parse(layout, input); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, input, now.Location()); err == nil {
+		return t, nil
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// The End of a Period is the last Second before the next one starts
+	endBefore := func(next time.Time) time.Time {
+		return next.Add(-time.Second)
+	}
+	switch strings.ToLower(input) {
+	case "now":
+		return now, nil
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "end-of-day":
+		return endBefore(today.AddDate(0, 0, 1)), nil
+	case "end-of-week":
+		daysToMonday := (8 - int(today.Weekday())) % 7
+		if daysToMonday == 0 {
+			daysToMonday = 7
+		}
+		return endBefore(today.AddDate(0, 0, daysToMonday)), nil
+	case "end-of-month":
+		return endBefore(time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())), nil
+	case "end-of-quarter":
+		nextQuarter := time.Month((int(now.Month())-1)/3*3 + 4)
+		return endBefore(time.Date(now.Year(), nextQuarter, 1, 0, 0, 0, 0, now.Location())), nil
+	case "end-of-year":
+		return endBefore(time.Date(now.Year()+1, time.January, 1, 0, 0, 0, 0, now.Location())), nil
+	}
+
+	d, err := parseCalendarDuration(input)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use RFC3339, a date like 2025-12-31, a duration like 7d or a phrase like end-of-quarter", input)
+	}
+	return now.AddDate(d.years, d.months, d.days).Add(d.clock), nil
+}
+
+// ParsePastTime parses a Point in Time like ParseRelativeTime, but Durations are counted back from now, e.g. "7d" means 7 Days ago.
+func ParsePastTime(input string, now time.Time) (time.Time, error) {
+	if d, err := parseCalendarDuration(strings.TrimSpace(input)); err == nil {
+		return now.AddDate(-d.years, -d.months, -d.days).Add(-d.clock), nil
+	}
+	return ParseRelativeTime(input, now)
+}
@@ -0,0 +1,149 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "7d", want: 7 * day},
+		{input: "2w", want: 14 * day},
+		{input: "1w2d3h", want: 9*day + 3*time.Hour},
+		{input: "3mo", want: 90 * day},
+		{input: "1y", want: 365 * day},
+		{input: "1y6mo", want: 545 * day},
+		{input: "10m", want: 10 * time.Minute},
+		{input: "1h30m15s", want: time.Hour + 30*time.Minute + 15*time.Second},
+		{input: "1.5h", want: 90 * time.Minute},
+		{input: " 14d ", want: 14 * day},
+		{input: "", wantErr: true},
+		{input: "abc", wantErr: true},
+		{input: "7x", wantErr: true},
+		{input: "d7", wantErr: true},
+		{input: "7d foo", wantErr: true},
+		{input: "-7d", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseDuration(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDuration(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	loc := time.UTC
+	// A Friday
+	now := time.Date(2025, time.January, 31, 10, 30, 0, 0, loc)
+	sunday := time.Date(2025, time.February, 2, 8, 0, 0, 0, loc)
+	monday := time.Date(2025, time.February, 3, 8, 0, 0, 0, loc)
+	leapYear := time.Date(2024, time.February, 29, 12, 0, 0, 0, loc)
+
+	tests := []struct {
+		name    string
+		input   string
+		now     time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "rfc3339", input: "2025-12-31T23:59:59Z", now: now, want: time.Date(2025, time.December, 31, 23, 59, 59, 0, loc)},
+		{name: "rfc3339 nano", input: "2025-12-31T23:59:59.5Z", now: now, want: time.Date(2025, time.December, 31, 23, 59, 59, 500000000, loc)},
+		{name: "date only", input: "2025-12-31", now: now, want: time.Date(2025, time.December, 31, 0, 0, 0, 0, loc)},
+		{name: "now", input: "now", now: now, want: now},
+		{name: "today", input: "today", now: now, want: time.Date(2025, time.January, 31, 0, 0, 0, 0, loc)},
+		{name: "tomorrow", input: "tomorrow", now: now, want: time.Date(2025, time.February, 1, 0, 0, 0, 0, loc)},
+		{name: "end of day", input: "end-of-day", now: now, want: time.Date(2025, time.January, 31, 23, 59, 59, 0, loc)},
+		{name: "end of week", input: "end-of-week", now: now, want: time.Date(2025, time.February, 2, 23, 59, 59, 0, loc)},
+		{name: "end of week on sunday", input: "end-of-week", now: sunday, want: time.Date(2025, time.February, 2, 23, 59, 59, 0, loc)},
+		{name: "end of week on monday", input: "end-of-week", now: monday, want: time.Date(2025, time.February, 9, 23, 59, 59, 0, loc)},
+		{name: "end of month", input: "end-of-month", now: now, want: time.Date(2025, time.January, 31, 23, 59, 59, 0, loc)},
+		{name: "end of february in leap year", input: "end-of-month", now: leapYear, want: time.Date(2024, time.February, 29, 23, 59, 59, 0, loc)},
+		{name: "end of quarter", input: "end-of-quarter", now: now, want: time.Date(2025, time.March, 31, 23, 59, 59, 0, loc)},
+		{name: "end of last quarter", input: "end-of-quarter", now: time.Date(2025, time.November, 15, 0, 0, 0, 0, loc), want: time.Date(2025, time.December, 31, 23, 59, 59, 0, loc)},
+		{name: "end of year", input: "end-of-year", now: now, want: time.Date(2025, time.December, 31, 23, 59, 59, 0, loc)},
+		{name: "phrase is case insensitive", input: "End-Of-Year", now: now, want: time.Date(2025, time.December, 31, 23, 59, 59, 0, loc)},
+		{name: "days", input: "7d", now: now, want: time.Date(2025, time.February, 7, 10, 30, 0, 0, loc)},
+		{name: "weeks days hours", input: "1w2d3h", now: now, want: time.Date(2025, time.February, 9, 13, 30, 0, 0, loc)},
+		{name: "month overflows short month", input: "1mo", now: now, want: time.Date(2025, time.March, 3, 10, 30, 0, 0, loc)},
+		{name: "months", input: "3mo", now: time.Date(2025, time.January, 15, 0, 0, 0, 0, loc), want: time.Date(2025, time.April, 15, 0, 0, 0, 0, loc)},
+		{name: "year from leap day", input: "1y", now: leapYear, want: time.Date(2025, time.March, 1, 12, 0, 0, 0, loc)},
+		{name: "year and months", input: "1y6mo", now: now, want: time.Date(2026, time.July, 31, 10, 30, 0, 0, loc)},
+		{name: "empty", input: "", now: now, wantErr: true},
+		{name: "unknown phrase", input: "end-of-decade", now: now, wantErr: true},
+		{name: "invalid date", input: "2025-13-01", now: now, wantErr: true},
+		{name: "invalid unit", input: "7x", now: now, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRelativeTime(tt.input, tt.now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRelativeTime(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRelativeTime(%q) returned error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseRelativeTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePastTime(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2025, time.March, 31, 10, 30, 0, 0, loc)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "days ago", input: "7d", want: time.Date(2025, time.March, 24, 10, 30, 0, 0, loc)},
+		{name: "weeks and hours ago", input: "1w3h", want: time.Date(2025, time.March, 24, 7, 30, 0, 0, loc)},
+		{name: "month ago overflows short month", input: "1mo", want: time.Date(2025, time.March, 3, 10, 30, 0, 0, loc)},
+		{name: "year ago", input: "1y", want: time.Date(2024, time.March, 31, 10, 30, 0, 0, loc)},
+		{name: "date only", input: "2025-01-01", want: time.Date(2025, time.January, 1, 0, 0, 0, 0, loc)},
+		{name: "rfc3339", input: "2025-01-01T12:00:00Z", want: time.Date(2025, time.January, 1, 12, 0, 0, 0, loc)},
+		{name: "phrase", input: "today", want: time.Date(2025, time.March, 31, 0, 0, 0, 0, loc)},
+		{name: "empty", input: "", wantErr: true},
+		{name: "invalid", input: "yesterday-ish", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePastTime(tt.input, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParsePastTime(%q) = %v, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePastTime(%q) returned error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParsePastTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
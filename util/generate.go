@@ -0,0 +1,307 @@
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+//go:embed wordlist.txt
+var wordlistData string
+
+var wordlist = strings.Fields(wordlistData)
+
+const (
+	lowercaseChars = "abcdefghijklmnopqrstuvwxyz"
+	uppercaseChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars     = "0123456789"
+	symbolChars    = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+	// ambiguousChars look alike in many Fonts
+	ambiguousChars = "Il1O0o|`'\""
+)
+
+// GeneratorPolicy describes how Passwords are generated
+type GeneratorPolicy struct {
+	Passphrase bool
+
+	Length           int
+	Lowercase        bool
+	Uppercase        bool
+	Digits           bool
+	Symbols          bool
+	ExcludeAmbiguous bool
+
+	Words         int
+	WordSeparator string
+	// WordCase is one of lowercase, uppercase or camelcase
+	WordCase string
+}
+
+// DefaultGeneratorPolicy is used if the Server has no Password Policy
+var DefaultGeneratorPolicy = GeneratorPolicy{
+	Length:           20,
+	Lowercase:        true,
+	Uppercase:        true,
+	Digits:           true,
+	Symbols:          true,
+	ExcludeAmbiguous: true,
+	Words:            6,
+	WordSeparator:    "-",
+	WordCase:         "lowercase",
+}
+
+// AddGeneratorFlags adds the Flags to adjust the Password Generator
+func AddGeneratorFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	d := DefaultGeneratorPolicy
+	flags.Int("length", d.Length, "Length of generated Passwords")
+	flags.Bool("lowercase", d.Lowercase, "Use lowercase Letters in generated Passwords")
+	flags.Bool("uppercase", d.Uppercase, "Use uppercase Letters in generated Passwords")
+	flags.Bool("digits", d.Digits, "Use Digits in generated Passwords")
+	flags.Bool("symbols", d.Symbols, "Use Symbols in generated Passwords")
+	flags.Bool("excludeAmbiguous", d.ExcludeAmbiguous, "Exclude look-alike Characters like I, l, 1, O and 0 from generated Passwords")
+	flags.Bool("passphrase", false, "Generate a Passphrase of Words instead of a Password")
+	flags.Int("words", d.Words, "Number of Words in generated Passphrases")
+	flags.String("wordSeparator", d.WordSeparator, "Separator between Words in generated Passphrases")
+	flags.String("wordCase", d.WordCase, "Case of Words in generated Passphrases: lowercase, uppercase or camelcase")
+}
+
+// GetGeneratorPolicy returns the Password Policy of the Server (if client is not nil and the Server has one) or the Default,
+// adjusted by all Generator Flags that were set explicitly.
+func GetGeneratorPolicy(ctx context.Context, cmd *cobra.Command, client *api.Client) (GeneratorPolicy, error) {
+	policy := DefaultGeneratorPolicy
+	if client != nil {
+		serverPolicy, err := GetServerGeneratorPolicy(ctx, client)
+		if err == nil {
+			policy = *serverPolicy
+		} else if viper.GetBool("debug") {
+			fmt.Fprintf(os.Stderr, "Using default Password Policy: %v\n", err)
+		}
+	}
+
+	flags := cmd.Flags()
+	var err error
+	for name, target := range map[string]*int{"length": &policy.Length, "words": &policy.Words} {
+		if flags.Changed(name) {
+			*target, err = flags.GetInt(name)
+			if err != nil {
+				return policy, err
+			}
+		}
+	}
+	for name, target := range map[string]*bool{
+		"lowercase":        &policy.Lowercase,
+		"uppercase":        &policy.Uppercase,
+		"digits":           &policy.Digits,
+		"symbols":          &policy.Symbols,
+		"excludeAmbiguous": &policy.ExcludeAmbiguous,
+	} {
+		if flags.Changed(name) {
+			*target, err = flags.GetBool(name)
+			if err != nil {
+				return policy, err
+			}
+			// Password Options imply generating a Password, even if the Server prefers Passphrases
+			policy.Passphrase = false
+		}
+	}
+	if flags.Changed("length") {
+		policy.Passphrase = false
+	}
+	for name, target := range map[string]*string{"wordSeparator": &policy.WordSeparator, "wordCase": &policy.WordCase} {
+		if flags.Changed(name) {
+			*target, err = flags.GetString(name)
+			if err != nil {
+				return policy, err
+			}
+			policy.Passphrase = true
+		}
+	}
+	if flags.Changed("words") {
+		policy.Passphrase = true
+	}
+	if flags.Changed("passphrase") {
+		policy.Passphrase, err = flags.GetBool("passphrase")
+		if err != nil {
+			return policy, err
+		}
+	}
+	return policy, nil
+}
+
+// serverPasswordPolicy is the Response of the Password Policies Endpoint
+type serverPasswordPolicy struct {
+	DefaultGenerator string `json:"default_generator"`
+	PasswordSettings struct {
+		Length                int  `json:"length"`
+		MaskUpper             bool `json:"mask_upper"`
+		MaskLower             bool `json:"mask_lower"`
+		MaskDigit             bool `json:"mask_digit"`
+		MaskParenthesis       bool `json:"mask_parenthesis"`
+		MaskChar1             bool `json:"mask_char1"`
+		MaskChar2             bool `json:"mask_char2"`
+		MaskChar3             bool `json:"mask_char3"`
+		MaskChar4             bool `json:"mask_char4"`
+		MaskChar5             bool `json:"mask_char5"`
+		ExcludeLookAlikeChars bool `json:"exclude_look_alike_chars"`
+	} `json:"password_generator_settings"`
+	PassphraseSettings struct {
+		Words         int    `json:"words"`
+		WordSeparator string `json:"word_separator"`
+		WordCase      string `json:"word_case"`
+	} `json:"passphrase_generator_settings"`
+}
+
+// GetServerGeneratorPolicy fetches the Password Policy configured on the Server, which is only available on newer Servers
+func GetServerGeneratorPolicy(ctx context.Context, client *api.Client) (*GeneratorPolicy, error) {
+	msg, err := client.DoCustomRequest(ctx, "GET", "/password-policies/settings.json", "v2", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Getting Password Policy: %w", err)
+	}
+
+	var settings serverPasswordPolicy
+	err = json.Unmarshal(msg.Body, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("Parsing Password Policy: %w", err)
+	}
+
+	ps := settings.PasswordSettings
+	policy := &GeneratorPolicy{
+		Passphrase:       settings.DefaultGenerator == "passphrase",
+		Length:           ps.Length,
+		Lowercase:        ps.MaskLower,
+		Uppercase:        ps.MaskUpper,
+		Digits:           ps.MaskDigit,
+		Symbols:          ps.MaskParenthesis || ps.MaskChar1 || ps.MaskChar2 || ps.MaskChar3 || ps.MaskChar4 || ps.MaskChar5,
+		ExcludeAmbiguous: ps.ExcludeLookAlikeChars,
+		Words:            settings.PassphraseSettings.Words,
+		WordSeparator:    settings.PassphraseSettings.WordSeparator,
+		WordCase:         settings.PassphraseSettings.WordCase,
+	}
+	if policy.Length == 0 {
+		policy.Length = DefaultGeneratorPolicy.Length
+	}
+	if policy.Words == 0 {
+		policy.Words = DefaultGeneratorPolicy.Words
+	}
+	return policy, nil
+}
+
+// GeneratePassword generates a random Password or Passphrase according to the Policy
+func GeneratePassword(policy GeneratorPolicy) (string, error) {
+	if policy.Passphrase {
+		return generatePassphrase(policy)
+	}
+
+	classes := []string{}
+	for _, class := range []struct {
+		enabled bool
+		chars   string
+	}{
+		{policy.Lowercase, lowercaseChars},
+		{policy.Uppercase, uppercaseChars},
+		{policy.Digits, digitChars},
+		{policy.Symbols, symbolChars},
+	} {
+		if !class.enabled {
+			continue
+		}
+		chars := class.chars
+		if policy.ExcludeAmbiguous {
+			chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(ambiguousChars, r) {
+					return -1
+				}
+				return r
+			}, chars)
+		}
+		classes = append(classes, chars)
+	}
+
+	if len(classes) == 0 {
+		return "", fmt.Errorf("At least one Character Class needs to be enabled")
+	}
+	if policy.Length < len(classes) {
+		return "", fmt.Errorf("The Length needs to be at least %d to use every enabled Character Class", len(classes))
+	}
+
+	// Use every enabled Class at least once, then fill up from all of them
+	password := make([]byte, 0, policy.Length)
+	for _, class := range classes {
+		c, err := randomElement(class)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+	all := strings.Join(classes, "")
+	for len(password) < policy.Length {
+		c, err := randomElement(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	// Shuffle so the guaranteed Characters are not always at the start
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+func generatePassphrase(policy GeneratorPolicy) (string, error) {
+	if policy.Words < 1 {
+		return "", fmt.Errorf("A Passphrase needs at least one Word")
+	}
+
+	words := make([]string, policy.Words)
+	for i := range words {
+		n, err := randomInt(len(wordlist))
+		if err != nil {
+			return "", err
+		}
+		word := wordlist[n]
+
+		switch strings.ToLower(policy.WordCase) {
+		case "", "lowercase":
+		case "uppercase":
+			word = strings.ToUpper(word)
+		case "camelcase":
+			word = strings.ToUpper(word[:1]) + word[1:]
+		default:
+			return "", fmt.Errorf("Unknown Word Case %q, use lowercase, uppercase or camelcase", policy.WordCase)
+		}
+		words[i] = word
+	}
+	return strings.Join(words, policy.WordSeparator), nil
+}
+
+func randomElement(chars string) (byte, error) {
+	n, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[n], nil
+}
+
+func randomInt(max int) (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, fmt.Errorf("Generating Random Number: %w", err)
+	}
+	return int(n.Int64()), nil
+}
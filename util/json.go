@@ -8,7 +8,9 @@ type PermissionJsonOutput struct {
 	AcoForeignKey     *string    `json:"aco_foreign_key,omitempty"`
 	Aro               *string    `json:"aro,omitempty"`
 	AroForeignKey     *string    `json:"aro_foreign_key,omitempty"`
+	AroName           *string    `json:"aro_name,omitempty"`
 	Type              *int       `json:"type,omitempty"`
+	TypeName          *string    `json:"type_name,omitempty"`
 	CreatedTimestamp  *time.Time `json:"created_timestamp,omitempty"`
 	ModifiedTimestamp *time.Time `json:"modified_timestamp,omitempty"`
 }
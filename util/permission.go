@@ -0,0 +1,233 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/google/cel-go/cel"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/pterm/pterm"
+)
+
+// Passbolt Permission Types
+const (
+	PermissionRead   = 1
+	PermissionUpdate = 7
+	PermissionOwner  = 15
+)
+
+// ParsePermissionType parses a Permission Type given as Name (read, update, owner) or Number (1, 7, 15)
+func ParsePermissionType(s string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "read", "1":
+		return PermissionRead, nil
+	case "update", "7":
+		return PermissionUpdate, nil
+	case "owner", "15":
+		return PermissionOwner, nil
+	}
+	return 0, fmt.Errorf("Unknown Permission Type %q, use read, update or owner", s)
+}
+
+// PermissionTypeName returns the Name of a Permission Type like "owner"
+func PermissionTypeName(pType int) string {
+	switch pType {
+	case PermissionRead:
+		return "read"
+	case PermissionUpdate:
+		return "update"
+	case PermissionOwner:
+		return "owner"
+	}
+	return strconv.Itoa(pType)
+}
+
+// DefaultPermissionColumns are shown by the Permission Listings if no Columns are given
+var DefaultPermissionColumns = []string{"ID", "Aco", "AcoForeignKey", "Aro", "AroForeignKey", "Type"}
+
+// PermissionColumnsHelp lists the possible Columns of Permission Listings
+const PermissionColumnsHelp = "Columns to return, possible Columns:\nID, Aco, AcoForeignKey, Aro, AroForeignKey, AroName, Type, TypeName, CreatedTimestamp, ModifiedTimestamp"
+
+// PermissionFilterHelp describes the --filter Flag of Permission Listings
+const PermissionFilterHelp = "CEL expression as filter for Permissions, all Columns can be used, e.g. --filter 'Aro == \"Group\" && TypeName == \"owner\"'"
+
+// PermissionCelEnvOptions defines the CEL environment for Permission filtering
+var PermissionCelEnvOptions = []cel.EnvOption{
+	cel.Variable("ID", cel.StringType),
+	cel.Variable("Aco", cel.StringType),
+	cel.Variable("AcoForeignKey", cel.StringType),
+	cel.Variable("Aro", cel.StringType),
+	cel.Variable("AroForeignKey", cel.StringType),
+	cel.Variable("AroName", cel.StringType),
+	cel.Variable("Type", cel.IntType),
+	cel.Variable("TypeName", cel.StringType),
+	cel.Variable("CreatedTimestamp", cel.TimestampType),
+	cel.Variable("ModifiedTimestamp", cel.TimestampType),
+}
+
+func permissionCelVars(p api.Permission, aroName string) map[string]any {
+	return map[string]any{
+		"ID":                p.ID,
+		"Aco":               p.ACO,
+		"AcoForeignKey":     p.ACOForeignKey,
+		"Aro":               p.ARO,
+		"AroForeignKey":     p.AROForeignKey,
+		"AroName":           aroName,
+		"Type":              p.Type,
+		"TypeName":          PermissionTypeName(p.Type),
+		"CreatedTimestamp":  permissionTime(p.Created),
+		"ModifiedTimestamp": permissionTime(p.Modified),
+	}
+}
+
+// permissionTime returns the Time of a Permission Timestamp, the zero Time if the Server did not send it
+func permissionTime(t *api.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.Time
+}
+
+// GetAroNames returns the Names of all Users and Groups the Permissions are granted to, indexed by their ID.
+// Users are named "Full Name <username>". The User and Group Lists are each only fetched once and only if needed.
+func GetAroNames(ctx context.Context, client *api.Client, permissions []api.Permission) (map[string]string, error) {
+	var needUsers, needGroups bool
+	for _, p := range permissions {
+		switch p.ARO {
+		case "User":
+			needUsers = true
+		case "Group":
+			needGroups = true
+		}
+	}
+
+	names := map[string]string{}
+	if needUsers {
+		users, err := client.GetUsers(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Listing Users: %w", err)
+		}
+		for _, c := range userCandidates(users) {
+			names[c.ID] = c.Display
+		}
+	}
+	if needGroups {
+		groups, err := client.GetGroups(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("Listing Groups: %w", err)
+		}
+		for _, g := range groups {
+			names[g.ID] = g.Name
+		}
+	}
+	return names, nil
+}
+
+// PrintPermissions prints Permissions as Table or JSON, optionally filtered by a CEL Expression
+func PrintPermissions(ctx context.Context, client *api.Client, permissions []api.Permission, columns []string, jsonOutput bool, filter string) error {
+	// Users and Groups are only listed if the Names are actually used
+	names := map[string]string{}
+	if jsonOutput || filter != "" || slices.ContainsFunc(columns, func(c string) bool { return strings.EqualFold(c, "AroName") }) {
+		var err error
+		names, err = GetAroNames(ctx, client, permissions)
+		if err != nil {
+			return err
+		}
+	}
+
+	if filter != "" {
+		program, err := InitCELProgram(filter, PermissionCelEnvOptions...)
+		if err != nil {
+			return fmt.Errorf("Parsing filter: %w", err)
+		}
+		filtered := []api.Permission{}
+		for _, p := range permissions {
+			val, _, err := (*program).ContextEval(ctx, permissionCelVars(p, names[p.AROForeignKey]))
+			if err != nil {
+				return err
+			}
+			if val.Value() == true {
+				filtered = append(filtered, p)
+			}
+		}
+		permissions = filtered
+	}
+
+	if jsonOutput {
+		outputPermissions := []PermissionJsonOutput{}
+		for i := range permissions {
+			aroName := names[permissions[i].AROForeignKey]
+			typeName := PermissionTypeName(permissions[i].Type)
+			var created, modified *time.Time
+			if permissions[i].Created != nil {
+				created = &permissions[i].Created.Time
+			}
+			if permissions[i].Modified != nil {
+				modified = &permissions[i].Modified.Time
+			}
+			outputPermissions = append(outputPermissions, PermissionJsonOutput{
+				ID:                &permissions[i].ID,
+				Aco:               &permissions[i].ACO,
+				AcoForeignKey:     &permissions[i].ACOForeignKey,
+				Aro:               &permissions[i].ARO,
+				AroForeignKey:     &permissions[i].AROForeignKey,
+				AroName:           &aroName,
+				Type:              &permissions[i].Type,
+				TypeName:          &typeName,
+				CreatedTimestamp:  created,
+				ModifiedTimestamp: modified,
+			})
+		}
+		jsonPermissions, err := json.MarshalIndent(outputPermissions, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonPermissions))
+		return nil
+	}
+
+	data := pterm.TableData{columns}
+	for _, permission := range permissions {
+		entry := make([]string, len(columns))
+		for i := range columns {
+			switch strings.ToLower(columns[i]) {
+			case "id":
+				entry[i] = permission.ID
+			case "aco":
+				entry[i] = permission.ACO
+			case "acoforeignkey":
+				entry[i] = permission.ACOForeignKey
+			case "aro":
+				entry[i] = permission.ARO
+			case "aroforeignkey":
+				entry[i] = permission.AROForeignKey
+			case "aroname":
+				entry[i] = shellescape.StripUnsafe(names[permission.AROForeignKey])
+			case "type":
+				entry[i] = strconv.Itoa(permission.Type)
+			case "typename":
+				entry[i] = PermissionTypeName(permission.Type)
+			case "createdtimestamp":
+				if permission.Created != nil {
+					entry[i] = permission.Created.Format(time.RFC3339)
+				}
+			case "modifiedtimestamp":
+				if permission.Modified != nil {
+					entry[i] = permission.Modified.Format(time.RFC3339)
+				}
+			default:
+				return fmt.Errorf("Unknown Column: %v", columns[i])
+			}
+		}
+		data = append(data, entry)
+	}
+
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	return nil
+}
@@ -0,0 +1,159 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/passbolt/go-passbolt/api"
+)
+
+var uuidRegexp = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// IsUUID performs a basic UUID validation in canonical 8-4-4-4-12 hex format.
+func IsUUID(s string) bool {
+	return uuidRegexp.MatchString(s)
+}
+
+// Candidate is an Entity that a Name can be resolved to
+type Candidate struct {
+	ID string
+	// Names this Candidate can be referenced by, e.g. Username and Full Name
+	Names []string
+	// Display is shown when listing ambiguous Candidates
+	Display string
+}
+
+// ResolveCandidate returns the ID of the single Candidate matching query.
+// Exact matches take precedence over case-insensitive ones, multiple matches are an error listing all matches.
+func ResolveCandidate(kind, query string, candidates []Candidate) (string, error) {
+	matches := matchCandidates(candidates, func(name string) bool { return name == query })
+	if len(matches) == 0 {
+		matches = matchCandidates(candidates, func(name string) bool { return strings.EqualFold(name, query) })
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("No %v found matching %q", kind, query)
+	case 1:
+		return matches[0].ID, nil
+	}
+
+	list := make([]string, len(matches))
+	for i, m := range matches {
+		list[i] = fmt.Sprintf("  - %v (%v)", m.Display, m.ID)
+	}
+	return "", fmt.Errorf("%q matches %d %vs, use the ID instead:\n%v", query, len(matches), kind, strings.Join(list, "\n"))
+}
+
+func matchCandidates(candidates []Candidate, match func(name string) bool) []Candidate {
+	matches := []Candidate{}
+	for _, c := range candidates {
+		for _, name := range c.Names {
+			if match(name) {
+				matches = append(matches, c)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// ResolveUserID returns the ID of the User referenced by an ID, Username (Email) or Full Name.
+func ResolveUserID(ctx context.Context, client *api.Client, input string) (string, error) {
+	ids, err := ResolveUserIDs(ctx, client, []string{input})
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// ResolveUserIDs resolves multiple Users at once, only fetching the User list if needed.
+func ResolveUserIDs(ctx context.Context, client *api.Client, inputs []string) ([]string, error) {
+	var candidates []Candidate
+	ids := make([]string, len(inputs))
+	for i, input := range inputs {
+		if input == "" || IsUUID(input) {
+			ids[i] = input
+			continue
+		}
+
+		if candidates == nil {
+			users, err := client.GetUsers(ctx, nil)
+			if err != nil {
+				return nil, fmt.Errorf("Listing Users: %w", err)
+			}
+			candidates = userCandidates(users)
+		}
+
+		id, err := ResolveCandidate("user", input, candidates)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+func userCandidates(users []api.User) []Candidate {
+	candidates := make([]Candidate, len(users))
+	for i, user := range users {
+		names := []string{user.Username}
+		display := user.Username
+		if user.Profile != nil {
+			fullName := user.Profile.FirstName + " " + user.Profile.LastName
+			names = append(names, fullName)
+			display = fmt.Sprintf("%v <%v>", fullName, user.Username)
+		}
+		candidates[i] = Candidate{
+			ID:      user.ID,
+			Names:   names,
+			Display: display,
+		}
+	}
+	return candidates
+}
+
+// ResolveGroupID returns the ID of the Group referenced by an ID or Name.
+func ResolveGroupID(ctx context.Context, client *api.Client, input string) (string, error) {
+	ids, err := ResolveGroupIDs(ctx, client, []string{input})
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// ResolveGroupIDs resolves multiple Groups at once, only fetching the Group list if needed.
+func ResolveGroupIDs(ctx context.Context, client *api.Client, inputs []string) ([]string, error) {
+	var candidates []Candidate
+	ids := make([]string, len(inputs))
+	for i, input := range inputs {
+		if input == "" || IsUUID(input) {
+			ids[i] = input
+			continue
+		}
+
+		if candidates == nil {
+			groups, err := client.GetGroups(ctx, nil)
+			if err != nil {
+				return nil, fmt.Errorf("Listing Groups: %w", err)
+			}
+			candidates = make([]Candidate, len(groups))
+			for j, group := range groups {
+				candidates[j] = Candidate{
+					ID:      group.ID,
+					Names:   []string{group.Name},
+					Display: group.Name,
+				}
+			}
+		}
+
+		id, err := ResolveCandidate("group", input, candidates)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,102 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+)
+
+// ResourceName returns the Name of a Resource, decrypting its Metadata if needed
+func ResourceName(ctx context.Context, client *api.Client, resource api.Resource) (string, error) {
+	rType, err := client.GetResourceTypeCached(ctx, resource.ResourceTypeID)
+	if err != nil {
+		return "", fmt.Errorf("Get ResourceType: %w", err)
+	}
+	_, name, _, _, _, _, err := helper.GetResourceFromDataWithOptions(client, resource, api.Secret{}, *rType, false)
+	if err != nil {
+		return "", fmt.Errorf("Decrypting Resource: %w", err)
+	}
+	return name, nil
+}
+
+// CopyResource Creates a Copy of a Resource in folderParentID and Returns the new Resources ID.
+// The Resource is copied with its Type and raw Secret, so Type specific Fields like TOTP or Custom Fields are kept.
+// An empty name keeps the original Name. The Expiry is copied too, Permissions are not.
+func CopyResource(ctx context.Context, client *api.Client, resource api.Resource, folderParentID, name string) (string, error) {
+	rType, err := client.GetResourceTypeCached(ctx, resource.ResourceTypeID)
+	if err != nil {
+		return "", fmt.Errorf("Get ResourceType: %w", err)
+	}
+
+	if len(resource.Secrets) == 0 {
+		secret, err := client.GetSecret(ctx, resource.ID)
+		if err != nil {
+			return "", fmt.Errorf("Getting Secret: %w", err)
+		}
+		resource.Secrets = []api.Secret{*secret}
+	}
+	secretData, err := client.DecryptMessage(resource.Secrets[0].Data)
+	if err != nil {
+		return "", fmt.Errorf("Decrypting Secret: %w", err)
+	}
+	encSecretData, err := client.EncryptMessage(secretData)
+	if err != nil {
+		return "", fmt.Errorf("Encrypting Secret: %w", err)
+	}
+
+	newResource := api.Resource{
+		ResourceTypeID: resource.ResourceTypeID,
+		FolderParentID: folderParentID,
+		Secrets:        []api.Secret{{Data: encSecretData}},
+		Expired:        resource.Expired,
+	}
+
+	if resource.Metadata != "" {
+		metadata, err := helper.GetResourceMetadata(ctx, client, &resource, rType)
+		if err != nil {
+			return "", fmt.Errorf("Get Resource Metadata: %w", err)
+		}
+		if name != "" {
+			var metadataMap map[string]any
+			err = json.Unmarshal([]byte(metadata), &metadataMap)
+			if err != nil {
+				return "", fmt.Errorf("Unmarshalling Metadata: %w", err)
+			}
+			metadataMap["name"] = name
+			data, err := json.Marshal(metadataMap)
+			if err != nil {
+				return "", fmt.Errorf("Marshalling Metadata: %w", err)
+			}
+			metadata = string(data)
+		}
+
+		keyID, keyType, key, err := client.GetMetadataKey(ctx, true)
+		if err != nil {
+			return "", fmt.Errorf("Get Metadata Key: %w", err)
+		}
+		encMetadata, err := client.EncryptMessageWithKey(key, metadata)
+		if err != nil {
+			return "", fmt.Errorf("Encrypting Metadata: %w", err)
+		}
+		newResource.MetadataKeyID = keyID
+		newResource.MetadataKeyType = keyType
+		newResource.Metadata = encMetadata
+	} else {
+		newResource.Name = resource.Name
+		if name != "" {
+			newResource.Name = name
+		}
+		newResource.Username = resource.Username
+		newResource.URI = resource.URI
+		newResource.Description = resource.Description
+	}
+
+	created, err := client.CreateResource(ctx, newResource)
+	if err != nil {
+		return "", fmt.Errorf("Creating Resource: %w", err)
+	}
+	return created.ID, nil
+}
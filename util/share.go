@@ -0,0 +1,262 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// groupPrefix marks a Principal of --read, --update and --owner as Group
+const groupPrefix = "group:"
+
+// AddShareFlags adds the Flags describing whom to share with
+func AddShareFlags(cmd *cobra.Command) {
+	cmd.Flags().IntP("type", "t", PermissionRead, "Permission Type for --user and --group (1 Read Only, 7 Can Update, 15 Owner, -1 Delete)")
+	cmd.Flags().StringArrayP("user", "u", []string{}, "User id's or usernames to share with")
+	cmd.Flags().StringArrayP("group", "g", []string{}, "Group id's or names to share with")
+	cmd.Flags().StringArray("read", []string{}, "Users to grant Read Only Permission, prefix Groups with \"group:\"")
+	cmd.Flags().StringArray("update", []string{}, "Users to grant Can Update Permission, prefix Groups with \"group:\"")
+	cmd.Flags().StringArray("owner", []string{}, "Users to grant Owner Permission, prefix Groups with \"group:\"")
+	cmd.Flags().Bool("revoke", false, "Revoke the Permissions of --user and --group instead of granting them")
+	cmd.Flags().Bool("replace", false, "Make the Permissions exactly the given ones, revoking all others")
+}
+
+// sharePrincipal is a User or Group to share with, before it is resolved to an ID
+type sharePrincipal struct {
+	aro   string
+	input string
+	pType int
+}
+
+// ShareSpec describes the Permissions a Share Command should result in
+type ShareSpec struct {
+	principals []sharePrincipal
+	// Ops contains the resolved Permission for every Principal, -1 revokes it
+	Ops     []helper.ShareOperation
+	Replace bool
+	Revoke  bool
+	// Additive never lowers or revokes existing Permissions
+	Additive bool
+}
+
+// GetShareSpec reads the Share Flags, Names are resolved later by Resolve
+func GetShareSpec(cmd *cobra.Command) (*ShareSpec, error) {
+	pType, err := cmd.Flags().GetInt("type")
+	if err != nil {
+		return nil, err
+	}
+	users, err := cmd.Flags().GetStringArray("user")
+	if err != nil {
+		return nil, err
+	}
+	groups, err := cmd.Flags().GetStringArray("group")
+	if err != nil {
+		return nil, err
+	}
+	revoke, err := cmd.Flags().GetBool("revoke")
+	if err != nil {
+		return nil, err
+	}
+	replace, err := cmd.Flags().GetBool("replace")
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &ShareSpec{Replace: replace, Revoke: revoke}
+	if revoke {
+		if replace {
+			return nil, fmt.Errorf("Either --revoke or --replace can be given, not both")
+		}
+		if cmd.Flags().Changed("type") {
+			return nil, fmt.Errorf("--type can't be used with --revoke")
+		}
+		pType = -1
+	}
+	if pType == -1 && replace {
+		return nil, fmt.Errorf("--type -1 can't be used with --replace, leave the Principal out instead")
+	}
+
+	for _, u := range users {
+		spec.principals = append(spec.principals, sharePrincipal{aro: "User", input: u, pType: pType})
+	}
+	for _, g := range groups {
+		spec.principals = append(spec.principals, sharePrincipal{aro: "Group", input: g, pType: pType})
+	}
+
+	for _, level := range []struct {
+		flag  string
+		pType int
+	}{
+		{"read", PermissionRead},
+		{"update", PermissionUpdate},
+		{"owner", PermissionOwner},
+	} {
+		inputs, err := cmd.Flags().GetStringArray(level.flag)
+		if err != nil {
+			return nil, err
+		}
+		if len(inputs) != 0 && revoke {
+			return nil, fmt.Errorf("--%v can't be used with --revoke, use --user and --group instead", level.flag)
+		}
+		for _, input := range inputs {
+			p := sharePrincipal{aro: "User", input: strings.TrimPrefix(input, "user:"), pType: level.pType}
+			if strings.HasPrefix(input, groupPrefix) {
+				p = sharePrincipal{aro: "Group", input: strings.TrimPrefix(input, groupPrefix), pType: level.pType}
+			}
+			spec.principals = append(spec.principals, p)
+		}
+	}
+
+	if len(spec.principals) == 0 {
+		return nil, fmt.Errorf("Nothing to share, use --user, --group, --read, --update or --owner")
+	}
+	return spec, nil
+}
+
+// Resolve resolves the Names of all Principals to IDs and fills Ops
+func (s *ShareSpec) Resolve(ctx context.Context, client *api.Client) error {
+	s.Ops = make([]helper.ShareOperation, len(s.principals))
+	seen := map[string]bool{}
+	for i, p := range s.principals {
+		var id string
+		var err error
+		if p.aro == "Group" {
+			id, err = ResolveGroupID(ctx, client, p.input)
+			if err != nil {
+				return fmt.Errorf("Resolving Groups: %w", err)
+			}
+		} else {
+			id, err = ResolveUserID(ctx, client, p.input)
+			if err != nil {
+				return fmt.Errorf("Resolving Users: %w", err)
+			}
+		}
+		if seen[p.aro+id] {
+			return fmt.Errorf("%v %q is given more than once", p.aro, p.input)
+		}
+		seen[p.aro+id] = true
+		s.Ops[i] = helper.ShareOperation{Type: p.pType, ARO: p.aro, AROID: id}
+	}
+	return nil
+}
+
+// Changes returns the Share Operations needed to get from the current Permissions to the specified ones.
+// Operations which would not change anything are left out, so the Result can be passed to helper.ShareResource.
+func (s *ShareSpec) Changes(current []api.Permission) []helper.ShareOperation {
+	existing := map[string]int{}
+	for _, p := range current {
+		existing[p.ARO+p.AROForeignKey] = p.Type
+	}
+
+	changes := []helper.ShareOperation{}
+	wanted := map[string]bool{}
+	for _, op := range s.Ops {
+		wanted[op.ARO+op.AROID] = true
+		pType, ok := existing[op.ARO+op.AROID]
+		if (op.Type == -1 && !ok) || op.Type == pType {
+			continue
+		}
+		if s.Additive && ok && (op.Type == -1 || op.Type < pType) {
+			continue
+		}
+		changes = append(changes, op)
+	}
+
+	if s.Replace && !s.Additive {
+		for _, p := range current {
+			if !wanted[p.ARO+p.AROForeignKey] {
+				changes = append(changes, helper.ShareOperation{Type: -1, ARO: p.ARO, AROID: p.AROForeignKey})
+			}
+		}
+	}
+	return changes
+}
+
+// ShareSpecFromPermissions returns a ShareSpec granting exactly the given Permissions, e.g. to copy them to another Resource
+func ShareSpecFromPermissions(permissions []api.Permission, additive bool) *ShareSpec {
+	spec := &ShareSpec{Replace: !additive, Additive: additive}
+	for _, p := range permissions {
+		spec.Ops = append(spec.Ops, helper.ShareOperation{Type: p.Type, ARO: p.ARO, AROID: p.AROForeignKey})
+	}
+	return spec
+}
+
+// PrintShareDiff prints the Permissions before and after applying the Changes
+func PrintShareDiff(ctx context.Context, client *api.Client, current []api.Permission, changes []helper.ShareOperation) error {
+	all := append([]api.Permission{}, current...)
+	for _, c := range changes {
+		all = append(all, api.Permission{ARO: c.ARO, AROForeignKey: c.AROID})
+	}
+	names, err := GetAroNames(ctx, client, all)
+	if err != nil {
+		return err
+	}
+
+	type row struct{ aro, id, before, after string }
+	rows := map[string]*row{}
+	for _, p := range current {
+		name := PermissionTypeName(p.Type)
+		rows[p.ARO+p.AROForeignKey] = &row{p.ARO, p.AROForeignKey, name, name}
+	}
+	for _, c := range changes {
+		r, ok := rows[c.ARO+c.AROID]
+		if !ok {
+			r = &row{aro: c.ARO, id: c.AROID}
+			rows[c.ARO+c.AROID] = r
+		}
+		r.after = ""
+		if c.Type != -1 {
+			r.after = PermissionTypeName(c.Type)
+		}
+	}
+
+	keys := make([]string, 0, len(rows))
+	for k := range rows {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	data := pterm.TableData{{"", "Aro", "AroName", "Before", "After"}}
+	for _, k := range keys {
+		r := rows[k]
+		symbol := " "
+		switch {
+		case r.before == "":
+			symbol = "+"
+		case r.after == "":
+			symbol = "-"
+		case r.before != r.after:
+			symbol = "~"
+		}
+		name := names[r.id]
+		if name == "" {
+			name = r.id
+		}
+		data = append(data, []string{symbol, r.aro, shellescape.StripUnsafe(name), r.before, r.after})
+	}
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+	return nil
+}
+
+// ResolvePrincipal resolves a User or, prefixed with "group:", a Group and returns its ARO ("User" or "Group") and ID
+func ResolvePrincipal(ctx context.Context, client *api.Client, input string) (string, string, error) {
+	if strings.HasPrefix(input, groupPrefix) {
+		id, err := ResolveGroupID(ctx, client, strings.TrimPrefix(input, groupPrefix))
+		if err != nil {
+			return "", "", fmt.Errorf("Resolving Group: %w", err)
+		}
+		return "Group", id, nil
+	}
+	id, err := ResolveUserID(ctx, client, input)
+	if err != nil {
+		return "", "", fmt.Errorf("Resolving User: %w", err)
+	}
+	return "User", id, nil
+}
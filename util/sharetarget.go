@@ -0,0 +1,95 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/passbolt/go-passbolt/api"
+	"github.com/passbolt/go-passbolt/helper"
+	"github.com/pterm/pterm"
+)
+
+// ShareTarget is a Resource or Folder whose Permissions are changed to match a ShareSpec
+type ShareTarget struct {
+	// Kind is either "Resource" or "Folder"
+	Kind    string
+	ID      string
+	Name    string
+	Current []api.Permission
+	Changes []helper.ShareOperation
+}
+
+// PlanShareTargets computes the Changes for every Target and prints them, only Targets with Changes are returned
+func PlanShareTargets(ctx context.Context, client *api.Client, targets []*ShareTarget, spec *ShareSpec) ([]*ShareTarget, error) {
+	pending := []*ShareTarget{}
+	for _, t := range targets {
+		t.Changes = spec.Changes(t.Current)
+		if len(t.Changes) == 0 {
+			continue
+		}
+		fmt.Printf("%v %v:\n", t.Kind, shellescape.StripUnsafe(t.Name))
+		err := PrintShareDiff(ctx, client, t.Current, t.Changes)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, t)
+	}
+	return pending, nil
+}
+
+// ApplyShareTargets applies the planned Changes and prints the Result for every Target
+func ApplyShareTargets(ctx context.Context, client *api.Client, pending []*ShareTarget) error {
+	failed := 0
+	data := pterm.TableData{{"Type", "ID", "Name", "Result"}}
+	for _, t := range pending {
+		var err error
+		if t.Kind == "Folder" {
+			err = helper.ShareFolder(ctx, client, t.ID, t.Changes)
+		} else {
+			err = helper.ShareResource(ctx, client, t.ID, t.Changes)
+		}
+		result := "OK"
+		if err != nil {
+			result = "Error: " + err.Error()
+			failed++
+		}
+		data = append(data, []string{t.Kind, t.ID, shellescape.StripUnsafe(t.Name), result})
+	}
+	pterm.DefaultTable.WithHasHeader().WithData(data).Render()
+
+	if failed != 0 {
+		return fmt.Errorf("%d of %d Targets failed", failed, len(pending))
+	}
+	return nil
+}
+
+// InheritPermissions applies spec to all Targets.
+// The Changes are shown and confirmed first, move is called (if set) after Confirmation but before any Permission is changed.
+func InheritPermissions(ctx context.Context, client *api.Client, spec *ShareSpec, targets []*ShareTarget, dryRun, yes bool, move func() error) error {
+	pending, err := PlanShareTargets(ctx, client, targets, spec)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		fmt.Println("No Permission Changes needed.")
+	} else if dryRun {
+		return nil
+	} else {
+		err = Confirm(fmt.Sprintf("Change the Permissions of %d Targets?", len(pending)), yes)
+		if err != nil {
+			return err
+		}
+	}
+
+	if move != nil {
+		err = move()
+		if err != nil {
+			return err
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	return ApplyShareTargets(ctx, client, pending)
+}
@@ -0,0 +1,201 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/spf13/cobra"
+)
+
+// SortKey is a single --sort entry. Expression is either a column name or a CEL expression
+// which is evaluated with the same variables as the --filter flag.
+type SortKey struct {
+	Expression string
+	Descending bool
+}
+
+// ParseSortKeys parses --sort values of the form <column|expression>[:asc|:desc].
+// Column names are matched case-insensitively against the variables of the CEL environment.
+func ParseSortKeys(sorts []string, options ...cel.EnvOption) ([]SortKey, error) {
+	if len(sorts) == 0 {
+		return nil, nil
+	}
+
+	env, err := cel.NewEnv(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]SortKey, 0, len(sorts))
+	for _, s := range sorts {
+		key := SortKey{Expression: s}
+		if i := strings.LastIndex(s, ":"); i != -1 {
+			switch strings.ToLower(s[i+1:]) {
+			case "desc":
+				key.Expression = s[:i]
+				key.Descending = true
+			case "asc":
+				key.Expression = s[:i]
+			}
+		}
+
+		key.Expression = strings.TrimSpace(key.Expression)
+		if key.Expression == "" {
+			return nil, fmt.Errorf("Empty Sort Key: %q", s)
+		}
+
+		// Map column names like "name" to the CEL Variable "Name"
+		for _, v := range env.Variables() {
+			if strings.EqualFold(v.Name(), key.Expression) {
+				key.Expression = v.Name()
+				break
+			}
+		}
+
+		_, issue := env.Compile(key.Expression)
+		if issue.Err() != nil {
+			return nil, fmt.Errorf("Parsing Sort Key %q: %w", s, issue.Err())
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SortKeysReferenceFields checks if any of the sort keys references one of the given field names.
+func SortKeysReferenceFields(keys []SortKey, fieldNames []string, options ...cel.EnvOption) (bool, error) {
+	for _, key := range keys {
+		refs, err := CELExpressionReferencesFields(key.Expression, fieldNames, options...)
+		if err != nil {
+			return false, err
+		}
+		if refs {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SortByKeys stable sorts items by the given keys, earlier keys take precedence.
+// vars returns the CEL activation for a single item, usually the same one used for filtering.
+func SortByKeys[T any](ctx context.Context, items []T, keys []SortKey, vars func(T) map[string]any, options ...cel.EnvOption) error {
+	if len(keys) == 0 || len(items) < 2 {
+		return nil
+	}
+
+	programs := make([]*cel.Program, len(keys))
+	for i, key := range keys {
+		program, err := InitCELProgram(key.Expression, options...)
+		if err != nil {
+			return fmt.Errorf("Parsing Sort Key %q: %w", key.Expression, err)
+		}
+		programs[i] = program
+	}
+
+	// Evaluate every key once per item instead of once per comparison
+	values := make([][]ref.Val, len(items))
+	for i := range items {
+		activation := vars(items[i])
+		values[i] = make([]ref.Val, len(keys))
+		for k, program := range programs {
+			val, _, err := (*program).ContextEval(ctx, activation)
+			if err != nil {
+				return fmt.Errorf("Evaluating Sort Key %q: %w", keys[k].Expression, err)
+			}
+			values[i][k] = val
+		}
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+
+	var sortErr error
+	sort.SliceStable(order, func(a, b int) bool {
+		for k, key := range keys {
+			cmp, err := compareValues(values[order[a]][k], values[order[b]][k])
+			if err != nil {
+				if sortErr == nil {
+					sortErr = fmt.Errorf("Sorting by %q: %w", key.Expression, err)
+				}
+				return false
+			}
+			if cmp == 0 {
+				continue
+			}
+			if key.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+	if sortErr != nil {
+		return sortErr
+	}
+
+	sorted := make([]T, len(items))
+	for i, idx := range order {
+		sorted[i] = items[idx]
+	}
+	copy(items, sorted)
+	return nil
+}
+
+func compareValues(a, b ref.Val) (int64, error) {
+	comparer, ok := a.(traits.Comparer)
+	if !ok {
+		return 0, fmt.Errorf("values of type %v are not sortable", a.Type())
+	}
+	result := comparer.Compare(b)
+	if types.IsError(result) {
+		return 0, fmt.Errorf("%v", result)
+	}
+	cmp, ok := result.(types.Int)
+	if !ok {
+		return 0, fmt.Errorf("unable to compare %v with %v", a.Type(), b.Type())
+	}
+	return int64(cmp), nil
+}
+
+// Paginate applies --offset and --limit to already sorted items. A limit of 0 means no limit.
+func Paginate[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// GetSortAndPagination returns the parsed --sort, --limit and --offset Flags of a list Command
+func GetSortAndPagination(cmd *cobra.Command, options ...cel.EnvOption) ([]SortKey, int, int, error) {
+	sorts, err := cmd.Flags().GetStringArray("sort")
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	sortKeys, err := ParseSortKeys(sorts, options...)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	offset, err := cmd.Flags().GetInt("offset")
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if limit < 0 || offset < 0 {
+		return nil, 0, 0, fmt.Errorf("limit and offset can't be negative")
+	}
+	return sortKeys, limit, offset, nil
+}